@@ -0,0 +1,403 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Bind populates the fields of the struct pointed to by dst with values from
+// b.
+//
+// Each field with a "config" tag is populated with the value associated with
+// the key named by the tag. The "default" tag specifies the value to use if
+// the key is undefined, otherwise the key is required. Fields of numeric and
+// time.Duration types also accept "min" and "max" tags, which have the same
+// meaning as the min and max parameters of the "Between" functions, such as
+// AsIntBetween().
+//
+// The supported field types are string, []byte, bool, the signed and unsigned
+// integer types, float32, float64, time.Duration, url.URL, *url.URL and Value.
+//
+// Fields of struct types are populated recursively, including embedded
+// structs, even if they are unexported. If such a field has a "config" tag it
+// is used as a prefix for the keys within the nested struct, as per Sub().
+// Otherwise, the keys within the nested struct are not prefixed.
+//
+// Fields with a tag value of "-" are ignored, as are fields of other types
+// that do not have a "config" tag.
+//
+// Rather than panicking, Bind() returns a KeyErrors value containing the
+// errors for every field that could not be populated. It panics if dst is not
+// a pointer to a struct, if any of the tags are malformed, or if a tagged
+// field has an unsupported type, such as a pointer to a struct other than
+// url.URL.
+func Bind(b Bucket, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("cannot bind to %T, expected a pointer to a struct", dst))
+	}
+
+	var errs KeyErrors
+	bindStruct(b, rv.Elem(), &errs)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	urlType      = reflect.TypeOf(url.URL{})
+	urlPtrType   = reflect.TypeOf(&url.URL{})
+	valueType    = reflect.TypeOf(Value{})
+)
+
+// bindStruct populates the fields of the struct v with values from b.
+func bindStruct(b Bucket, v reflect.Value, errs *KeyErrors) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if !f.IsExported() && !(f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		k, ok := f.Tag.Lookup("config")
+		if k == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		if f.Type.Kind() == reflect.Struct &&
+			f.Type != urlType &&
+			f.Type != valueType {
+			if k == "" {
+				bindStruct(b, fv, errs)
+			} else {
+				bindStruct(Sub(b, k), fv, errs)
+			}
+		} else if ok {
+			bindField(b, k, f, fv, errs)
+		}
+	}
+}
+
+// bindField populates the field v, described by f, with the value associated
+// with k.
+//
+// If the value can not be obtained, the panic value is added to errs.
+func bindField(
+	b Bucket,
+	k string,
+	f reflect.StructField,
+	v reflect.Value,
+	errs *KeyErrors,
+) {
+	defer func() {
+		if p := recover(); p != nil {
+			if err, ok := p.(KeyError); ok {
+				if e, ok := err.(InvalidDefaultValue); ok && e.Source == "" {
+					e.Source = fmt.Sprintf("the default tag of the %s field", f.Name)
+					err = e
+				}
+
+				*errs = append(*errs, err)
+				return
+			}
+
+			panic(p)
+		}
+	}()
+
+	def, hasDefault := f.Tag.Lookup("default")
+
+	switch f.Type {
+	case durationType:
+		bindDuration(b, k, f, v, def, hasDefault)
+		return
+
+	case urlType, urlPtrType:
+		var u *url.URL
+		if hasDefault {
+			u = AsURLDefault(b, k, def)
+		} else {
+			u = AsURL(b, k)
+		}
+
+		if f.Type == urlType {
+			v.Set(reflect.ValueOf(u).Elem())
+		} else {
+			v.Set(reflect.ValueOf(u))
+		}
+
+		return
+
+	case valueType:
+		var x Value
+		if hasDefault {
+			x = b.GetDefault(k, def)
+		} else if x = b.Get(k); x.IsZero() {
+			panic(notDefined(b, k))
+		}
+
+		v.Set(reflect.ValueOf(x))
+		return
+	}
+
+	switch f.Type.Kind() {
+	case reflect.String:
+		if hasDefault {
+			v.SetString(AsStringDefault(b, k, def))
+		} else {
+			v.SetString(AsString(b, k))
+		}
+
+	case reflect.Slice:
+		if f.Type.Elem().Kind() != reflect.Uint8 {
+			panic(fmt.Sprintf("cannot bind %s, unsupported type %s", f.Name, f.Type))
+		}
+
+		if hasDefault {
+			v.SetBytes(AsBytesDefault(b, k, []byte(def)))
+		} else {
+			v.SetBytes(AsBytes(b, k))
+		}
+
+	case reflect.Bool:
+		if !hasDefault {
+			v.SetBool(AsBool(b, k))
+		} else if d, ok := parseBool(def); ok {
+			v.SetBool(AsBoolDefault(b, k, d))
+		} else {
+			panic(InvalidDefaultValue{
+				Key:          qualify(b, k),
+				DefaultValue: def,
+				Explanation:  boolExplanation,
+			})
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bindInt(b, k, f, v, def, hasDefault)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bindUint(b, k, f, v, def, hasDefault)
+
+	case reflect.Float32, reflect.Float64:
+		bindFloat(b, k, f, v, def, hasDefault)
+
+	default:
+		panic(fmt.Sprintf("cannot bind %s, unsupported type %s", f.Name, f.Type))
+	}
+}
+
+func bindInt(
+	b Bucket,
+	k string,
+	f reflect.StructField,
+	v reflect.Value,
+	def string,
+	hasDefault bool,
+) {
+	bitSize := f.Type.Bits()
+	min := int64(-1) << (bitSize - 1)
+	max := -(min + 1)
+
+	parse := func(s string) (int64, error) {
+		return strconv.ParseInt(s, 10, bitSize)
+	}
+
+	min = rangeTag(f, "min", min, parse)
+	max = rangeTag(f, "max", max, parse)
+	a := bindAccessor("AsInt", f, hasDefault)
+
+	if !hasDefault {
+		v.SetInt(asInt(b, k, a, bitSize, min, max))
+		return
+	}
+
+	d, err := parse(def)
+	if err != nil {
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: def,
+			Explanation: fmt.Sprintf(
+				`expected an integer between %d and %d (inclusive)`,
+				min,
+				max,
+			),
+		})
+	}
+
+	v.SetInt(asIntDefault(b, k, a, bitSize, d, min, max))
+}
+
+func bindUint(
+	b Bucket,
+	k string,
+	f reflect.StructField,
+	v reflect.Value,
+	def string,
+	hasDefault bool,
+) {
+	bitSize := f.Type.Bits()
+	min := uint64(0)
+	max := uint64(math.MaxUint64) >> (64 - bitSize)
+
+	parse := func(s string) (uint64, error) {
+		return strconv.ParseUint(s, 10, bitSize)
+	}
+
+	min = rangeTag(f, "min", min, parse)
+	max = rangeTag(f, "max", max, parse)
+	a := bindAccessor("AsUint", f, hasDefault)
+
+	if !hasDefault {
+		v.SetUint(asUint(b, k, a, bitSize, min, max))
+		return
+	}
+
+	d, err := parse(def)
+	if err != nil {
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: def,
+			Explanation: fmt.Sprintf(
+				`expected an integer between %d and %d (inclusive)`,
+				min,
+				max,
+			),
+		})
+	}
+
+	v.SetUint(asUintDefault(b, k, a, bitSize, d, min, max))
+}
+
+func bindFloat(
+	b Bucket,
+	k string,
+	f reflect.StructField,
+	v reflect.Value,
+	def string,
+	hasDefault bool,
+) {
+	bitSize := f.Type.Bits()
+	max := math.MaxFloat64
+	if bitSize == 32 {
+		max = math.MaxFloat32
+	}
+	min := -max
+
+	parse := func(s string) (float64, error) {
+		return strconv.ParseFloat(s, bitSize)
+	}
+
+	min = rangeTag(f, "min", min, parse)
+	max = rangeTag(f, "max", max, parse)
+	a := bindAccessor("AsFloat", f, hasDefault)
+
+	if !hasDefault {
+		v.SetFloat(asFloat(b, k, a, bitSize, min, max))
+		return
+	}
+
+	d, err := parse(def)
+	if err != nil {
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: def,
+			Explanation:  fmt.Sprintf(`expected a %d-bit floating-point number`, bitSize),
+		})
+	}
+
+	v.SetFloat(asFloatDefault(b, k, a, bitSize, d, min, max))
+}
+
+func bindDuration(
+	b Bucket,
+	k string,
+	f reflect.StructField,
+	v reflect.Value,
+	def string,
+	hasDefault bool,
+) {
+	min := rangeTag(f, "min", math.MinInt64, time.ParseDuration)
+	max := rangeTag(f, "max", math.MaxInt64, time.ParseDuration)
+	a := bindAccessor("AsDuration", f, hasDefault)
+
+	if !hasDefault {
+		v.SetInt(int64(asDuration(b, k, a, min, max)))
+		return
+	}
+
+	d, err := time.ParseDuration(def)
+	if err != nil {
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: def,
+			Explanation:  `expected a duration`,
+		})
+	}
+
+	v.SetInt(int64(asDurationDefault(b, k, a, d, min, max)))
+}
+
+// bindAccessor returns the accessor used to record accesses to the field f.
+//
+// It is named after the function that is equivalent to the field's tags, such
+// as AsInt32DefaultBetween().
+func bindAccessor(name string, f reflect.StructField, hasDefault bool) accessor {
+	switch f.Type.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if f.Type != durationType {
+			name += strconv.Itoa(f.Type.Bits())
+		}
+	}
+
+	if hasDefault {
+		name += "Default"
+	}
+
+	_, hasMin := f.Tag.Lookup("min")
+	_, hasMax := f.Tag.Lookup("max")
+	ranged := hasMin || hasMax
+
+	if ranged {
+		name += "Between"
+	}
+
+	return accessor{name, ranged}
+}
+
+// rangeTag returns the value of the "min" or "max" tag of f, parsed using
+// parse. It returns v if the tag is not present.
+//
+// It panics if the tag is malformed.
+func rangeTag[T any](
+	f reflect.StructField,
+	tag string,
+	v T,
+	parse func(string) (T, error),
+) T {
+	s, ok := f.Tag.Lookup(tag)
+	if !ok {
+		return v
+	}
+
+	v, err := parse(s)
+	if err != nil {
+		panic(fmt.Sprintf("cannot bind %s, invalid %s tag (%#v): %s", f.Name, tag, s, err))
+	}
+
+	return v
+}
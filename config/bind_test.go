@@ -0,0 +1,238 @@
+package config_test
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Bind()", func() {
+	type database struct {
+		Host string `config:"HOST"`
+		Port uint16 `config:"PORT" default:"5432"`
+	}
+
+	type settings struct {
+		Name     string        `config:"NAME"`
+		Secret   []byte        `config:"SECRET" default:"<secret>"`
+		Debug    bool          `config:"DEBUG" default:"off"`
+		Workers  int           `config:"WORKERS" default:"4" min:"1" max:"16"`
+		Offset   int8          `config:"OFFSET" default:"-1"`
+		Ratio    float64       `config:"RATIO" default:"0.5" min:"0" max:"1"`
+		Timeout  time.Duration `config:"TIMEOUT" default:"30s" min:"1s" max:"5m"`
+		Endpoint *url.URL      `config:"ENDPOINT" default:"http://localhost"`
+		Raw      Value         `config:"RAW" default:"<raw>"`
+		DB       database      `config:"DB_"`
+
+		Ignored  string `config:"-"`
+		Untagged string
+		private  string `config:"PRIVATE"`
+	}
+
+	It("populates the fields of the struct", func() {
+		b := Map{
+			"NAME":     String("<name>"),
+			"SECRET":   String("<bytes>"),
+			"DEBUG":    String("yes"),
+			"WORKERS":  String("8"),
+			"OFFSET":   String("-100"),
+			"RATIO":    String("0.25"),
+			"TIMEOUT":  String("1m"),
+			"ENDPOINT": String("https://example.org/path"),
+			"RAW":      String("<value>"),
+			"DB_HOST":  String("<host>"),
+			"DB_PORT":  String("5433"),
+			"-":        String("<ignored>"),
+			"PRIVATE":  String("<private>"),
+		}
+
+		var s settings
+		err := Bind(b, &s)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(s.Name).To(Equal("<name>"))
+		Expect(s.Secret).To(Equal([]byte("<bytes>")))
+		Expect(s.Debug).To(BeTrue())
+		Expect(s.Workers).To(Equal(8))
+		Expect(s.Offset).To(BeNumerically("==", -100))
+		Expect(s.Ratio).To(Equal(0.25))
+		Expect(s.Timeout).To(Equal(time.Minute))
+		Expect(s.Endpoint.String()).To(Equal("https://example.org/path"))
+		Expect(s.Raw).To(Equal(String("<value>")))
+		Expect(s.DB.Host).To(Equal("<host>"))
+		Expect(s.DB.Port).To(BeNumerically("==", 5433))
+		Expect(s.Ignored).To(BeEmpty())
+		Expect(s.Untagged).To(BeEmpty())
+		Expect(s.private).To(BeEmpty())
+	})
+
+	It("uses the defaults for undefined keys", func() {
+		b := Map{
+			"NAME":    String("<name>"),
+			"DB_HOST": String("<host>"),
+		}
+
+		var s settings
+		err := Bind(b, &s)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(s.Secret).To(Equal([]byte("<secret>")))
+		Expect(s.Debug).To(BeFalse())
+		Expect(s.Workers).To(Equal(4))
+		Expect(s.Offset).To(BeNumerically("==", -1))
+		Expect(s.Ratio).To(Equal(0.5))
+		Expect(s.Timeout).To(Equal(30 * time.Second))
+		Expect(s.Endpoint.String()).To(Equal("http://localhost"))
+		Expect(s.Raw).To(Equal(String("<raw>")))
+		Expect(s.DB.Port).To(BeNumerically("==", 5432))
+	})
+
+	It("populates untagged nested structs without a prefix", func() {
+		type outer struct {
+			database
+		}
+
+		b := Map{"HOST": String("<host>")}
+
+		var s outer
+		err := Bind(b, &s)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(s.Host).To(Equal("<host>"))
+	})
+
+	It("returns every error that occurs", func() {
+		b := Map{
+			"WORKERS": String("100"),
+			"TIMEOUT": String("<invalid>"),
+			"DB_PORT": String("-1"),
+		}
+
+		var s settings
+		err := Bind(b, &s)
+
+		Expect(err).To(Equal(KeyErrors{
+			NotDefined{Key: "NAME"},
+			InvalidValue{
+				Key:         "WORKERS",
+				Value:       "100",
+				Explanation: `expected an integer between 1 and 16 (inclusive)`,
+			},
+			InvalidValue{
+				Key:         "TIMEOUT",
+				Value:       "<invalid>",
+				Explanation: `expected a duration`,
+			},
+			NotDefined{Key: "DB_HOST"},
+			InvalidValue{
+				Key:         "DB_PORT",
+				Value:       "-1",
+				Explanation: `expected an integer between 0 and 65535 (inclusive)`,
+			},
+		}))
+
+		var nd NotDefined
+		Expect(errors.As(err, &nd)).To(BeTrue())
+		Expect(nd.Key).To(Equal("NAME"))
+	})
+
+	It("returns an error if a default value is invalid", func() {
+		type invalid struct {
+			Debug   bool          `config:"DEBUG" default:"<invalid>"`
+			Workers int           `config:"WORKERS" default:"20" max:"16"`
+			Timeout time.Duration `config:"TIMEOUT" default:"<invalid>"`
+		}
+
+		var s invalid
+		err := Bind(Map{}, &s)
+
+		Expect(err).To(Equal(KeyErrors{
+			InvalidDefaultValue{
+				Key:          "DEBUG",
+				DefaultValue: "<invalid>",
+				Explanation:  `expected a boolean ("true", "false", "yes", "no", "on" or "off")`,
+				Source:       "the default tag of the Debug field",
+			},
+			InvalidDefaultValue{
+				Key:          "WORKERS",
+				DefaultValue: "20",
+				Explanation:  `expected an integer between -9223372036854775808 and 16 (inclusive)`,
+				Source:       "the default tag of the Workers field",
+			},
+			InvalidDefaultValue{
+				Key:          "TIMEOUT",
+				DefaultValue: "<invalid>",
+				Explanation:  `expected a duration`,
+				Source:       "the default tag of the Timeout field",
+			},
+		}))
+	})
+
+	It("panics if dst is not a pointer to a struct", func() {
+		Expect(func() {
+			var s settings
+			Bind(Map{}, s)
+		}).To(PanicWith(MatchRegexp(`cannot bind to .+settings, expected a pointer to a struct`)))
+	})
+
+	It("panics if a range tag is malformed", func() {
+		type invalid struct {
+			Workers int `config:"WORKERS" min:"<invalid>"`
+		}
+
+		Expect(func() {
+			var s invalid
+			Bind(Map{}, &s)
+		}).To(PanicWith(MatchRegexp(`cannot bind Workers, invalid min tag \("<invalid>"\)`)))
+	})
+
+	It("panics if a field has an unsupported type", func() {
+		type invalid struct {
+			Values []string `config:"VALUES"`
+		}
+
+		Expect(func() {
+			var s invalid
+			Bind(Map{}, &s)
+		}).To(PanicWith(`cannot bind Values, unsupported type []string`))
+	})
+
+	It("panics if a field is a pointer to a struct other than url.URL", func() {
+		type invalid struct {
+			DB *database `config:"DB_"`
+		}
+
+		Expect(func() {
+			var s invalid
+			Bind(Map{}, &s)
+		}).To(PanicWith(MatchRegexp(`cannot bind DB, unsupported type \*.+database`)))
+	})
+
+	It("populates untagged struct fields without a prefix", func() {
+		type embedded struct {
+			Name string `config:"NAME"`
+		}
+
+		type withUntagged struct {
+			embedded
+			DB      database
+			Ignored database `config:"-"`
+		}
+
+		b := Map{
+			"NAME": String("<name>"),
+			"HOST": String("<host>"),
+		}
+
+		var s withUntagged
+		err := Bind(b, &s)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(s.Name).To(Equal("<name>"))
+		Expect(s.DB).To(Equal(database{Host: "<host>", Port: 5432}))
+		Expect(s.Ignored).To(Equal(database{}))
+	})
+})
@@ -10,11 +10,18 @@ import (
 // It returns true if the value "true", "yes" or "on", or false if the value is
 // "false", "no" or "off".
 func AsBool(b Bucket, k string) bool {
-	if v, ok := asBool(b, k); ok {
-		return v
-	}
+	return asBool(b, k, accessor{"AsBool", false})
+}
 
-	panic(NotDefined{k})
+// TryAsBool returns the boolean representation of the value associated with k,
+// or an error if unable to do so.
+//
+// It returns true if the value "true", "yes" or "on", or false if the value is
+// "false", "no" or "off".
+func TryAsBool(b Bucket, k string) (bool, error) {
+	return try(func() bool {
+		return asBool(b, k, accessor{"TryAsBool", false})
+	})
 }
 
 // AsBoolT returns the boolean representation of the value associated with k, or
@@ -23,16 +30,42 @@ func AsBool(b Bucket, k string) bool {
 // It returns true if the value "true", "yes" or "on", or false if the value is
 // "false", "no" or "off".
 func AsBoolT(b Bucket, k string) bool {
-	return AsBoolDefault(b, k, true)
+	return asBoolDefault(b, k, accessor{"AsBoolT", false}, true)
+}
+
+// TryAsBoolT returns the boolean representation of the value associated with k,
+// or true if k is undefined.
+//
+// It returns true if the value "true", "yes" or "on", or false if the value is
+// "false", "no" or "off".
+//
+// It returns an error if the value is invalid.
+func TryAsBoolT(b Bucket, k string) (bool, error) {
+	return try(func() bool {
+		return asBoolDefault(b, k, accessor{"TryAsBoolT", false}, true)
+	})
 }
 
 // AsBoolF returns the boolean representation of the value associated with k, or
-// true if k is undefined.
+// false if k is undefined.
 //
 // It returns true if the value "true", "yes" or "on", or false if the value is
 // "false", "no" or "off".
 func AsBoolF(b Bucket, k string) bool {
-	return AsBoolDefault(b, k, false)
+	return asBoolDefault(b, k, accessor{"AsBoolF", false}, false)
+}
+
+// TryAsBoolF returns the boolean representation of the value associated with k,
+// or false if k is undefined.
+//
+// It returns true if the value "true", "yes" or "on", or false if the value is
+// "false", "no" or "off".
+//
+// It returns an error if the value is invalid.
+func TryAsBoolF(b Bucket, k string) (bool, error) {
+	return try(func() bool {
+		return asBoolDefault(b, k, accessor{"TryAsBoolF", false}, false)
+	})
 }
 
 // AsBoolDefault returns the boolean representation of the value associated with
@@ -41,32 +74,88 @@ func AsBoolF(b Bucket, k string) bool {
 // It returns true if the value "true", "yes" or "on", or false if the value is
 // "false", "no" or "off".
 func AsBoolDefault(b Bucket, k string, v bool) bool {
-	if v, ok := asBool(b, k); ok {
-		return v
-	}
+	return asBoolDefault(b, k, accessor{"AsBoolDefault", false}, v)
+}
 
-	return v
+// TryAsBoolDefault returns the boolean representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns true if the value "true", "yes" or "on", or false if the value is
+// "false", "no" or "off".
+//
+// It returns an error if the value is invalid.
+func TryAsBoolDefault(b Bucket, k string, v bool) (bool, error) {
+	return try(func() bool {
+		return asBoolDefault(b, k, accessor{"TryAsBoolDefault", false}, v)
+	})
 }
 
-func asBool(b Bucket, k string) (bool, bool) {
-	x := b.Get(k)
+func tryAsBool(
+	b Bucket,
+	k string,
+	a accessor,
+) (bool, bool) {
+	x := access(b, k, a)
 
 	if x.IsZero() {
 		return false, false
 	}
 
-	s := mustAsString(k, x)
+	s := mustAsString(qualify(b, k), x)
+
+	if v, ok := parseBool(s); ok {
+		return v, true
+	}
+
+	panic(InvalidValue{
+		Key:         qualify(b, k),
+		Value:       s,
+		Explanation: boolExplanation,
+		Source:      x.Source(),
+	})
+}
+
+func asBool(
+	b Bucket,
+	k string,
+	a accessor,
+) bool {
+	if v, ok := tryAsBool(b, k, a); ok {
+		return v
+	}
+
+	panic(notDefined(b, k))
+}
+
+func asBoolDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	d bool,
+) bool {
+	annotateDefault(b, k, a, d)
+
+	if v, ok := tryAsBool(b, k, a); ok {
+		return v
+	}
 
+	return d
+}
+
+// boolExplanation is the explanation used in errors that occur when a value
+// can not be parsed by parseBool().
+const boolExplanation = `expected a boolean ("true", "false", "yes", "no", "on" or "off")`
+
+// parseBool parses the boolean representation of s.
+//
+// ok is false if s is not one of the accepted values.
+func parseBool(s string) (v, ok bool) {
 	switch strings.ToLower(s) {
 	case "true", "yes", "on":
 		return true, true
 	case "false", "no", "off":
 		return false, true
 	default:
-		panic(InvalidValue{
-			k,
-			s,
-			`expected a boolean ("true", "false", "yes", "no", "on" or "off")`,
-		})
+		return false, false
 	}
 }
@@ -25,6 +25,21 @@ type Bucket interface {
 	Each(fn EachFunc) bool
 }
 
+// fallibleBucket is a Bucket that enumerates its keys by reading from a source
+// that may fail, such as a directory.
+//
+// Each() visits no keys if the source can not be read, such that the bucket
+// appears empty. tryEach() allows callers that must distinguish an unreadable
+// source from an empty one, such as WatchDirectory(), to obtain the error.
+type fallibleBucket interface {
+	Bucket
+
+	// tryEach calls fn for each key/value pair in the bucket, as per Each().
+	//
+	// It returns an error if the keys can not be enumerated.
+	tryEach(fn EachFunc) (bool, error)
+}
+
 // Map is an in-memory implementation of Bucket.
 type Map map[string]Value
 
@@ -2,7 +2,6 @@ package config
 
 import (
 	"bytes"
-	"fmt"
 	"io"
 	"io/ioutil"
 )
@@ -47,37 +46,86 @@ func (s *bytesSource) AsBytes() ([]byte, error) {
 	return s.value, nil
 }
 
+func (s *bytesSource) Source() string {
+	return ""
+}
+
 // AsBytes returns the byte-slice representation of the value associated with k
 // or panics if unable to do so.
 func AsBytes(b Bucket, k string) []byte {
-	if v, ok := asBytes(b, k); ok {
-		return v
-	}
+	return asBytes(b, k, accessor{"AsBytes", false})
+}
 
-	panic(NotDefined{k})
+// TryAsBytes returns the byte-slice representation of the value associated with
+// k, or an error if unable to do so.
+func TryAsBytes(b Bucket, k string) ([]byte, error) {
+	return try(func() []byte {
+		return asBytes(b, k, accessor{"TryAsBytes", false})
+	})
 }
 
 // AsBytesDefault returns the byte-slice representation of the value associated
 // with k, or the default value v if k is undefined.
 func AsBytesDefault(b Bucket, k string, v []byte) []byte {
-	if x, ok := asBytes(b, k); ok {
-		return x
-	}
+	return asBytesDefault(b, k, accessor{"AsBytesDefault", false}, v)
+}
 
-	return v
+// TryAsBytesDefault returns the byte-slice representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsBytesDefault(b Bucket, k string, v []byte) ([]byte, error) {
+	return try(func() []byte {
+		return asBytesDefault(b, k, accessor{"TryAsBytesDefault", false}, v)
+	})
 }
 
-func asBytes(b Bucket, k string) ([]byte, bool) {
-	x := b.Get(k)
+func tryAsBytes(
+	b Bucket,
+	k string,
+	a accessor,
+) ([]byte, bool) {
+	x := access(b, k, a)
 
 	if x.IsZero() {
 		return nil, false
 	}
 
-	s, err := x.AsBytes()
+	return mustAsBytes(qualify(b, k), x), true
+}
+
+func asBytes(
+	b Bucket,
+	k string,
+	a accessor,
+) []byte {
+	if v, ok := tryAsBytes(b, k, a); ok {
+		return v
+	}
+
+	panic(notDefined(b, k))
+}
+
+func asBytesDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	d []byte,
+) []byte {
+	annotateDefault(b, k, a, string(d))
+
+	if v, ok := tryAsBytes(b, k, a); ok {
+		return v
+	}
+
+	return d
+}
+
+func mustAsBytes(k string, v Value) []byte {
+	data, err := v.AsBytes()
 	if err != nil {
-		panic(fmt.Sprintf("cannot read %s: %s", k, err))
+		panic(unreadable(k, err))
 	}
 
-	return s, true
+	return data
 }
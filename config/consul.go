@@ -0,0 +1,223 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConsulSettings describes how to connect to a Consul agent.
+type ConsulSettings struct {
+	// Address is the URL of the Consul HTTP API. If it does not include a
+	// scheme, "http" is assumed. The default is "http://127.0.0.1:8500".
+	Address string
+
+	// Token is the ACL token used to authenticate requests, if any.
+	Token string
+
+	// Datacenter is the name of the datacenter to query. The default is the
+	// datacenter of the agent.
+	Datacenter string
+
+	// Client is the HTTP client used to make requests. The default is
+	// http.DefaultClient. Its timeout, if any, must exceed WaitTime.
+	Client *http.Client
+
+	// WaitTime is the maximum amount of time that a blocking query waits for
+	// a change before returning. The default is 5 minutes.
+	WaitTime time.Duration
+}
+
+// ConsulKV returns a bucket that produces configuration values from the keys
+// in Consul's key/value store that begin with prefix.
+//
+// The bucket key is the Consul key with the prefix removed, such that the
+// Consul key "shared/HTTP_TIMEOUT" is available as HTTP_TIMEOUT when prefix is
+// "shared/". Folders and keys with empty values are ignored.
+//
+// Changes are detected using Consul's blocking queries, such that they are
+// applied as soon as they occur, without polling. Queries that return without
+// a change are rate-limited. The WatchInterval() option sets the delay before
+// retrying after a failed query.
+//
+// It returns an error if the keys can not be read initially.
+func ConsulKV(s ConsulSettings, prefix string, opts ...WatchOption) (*WatchedBucket, error) {
+	c, err := newConsulClient(s, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWatchedBucket(c.load, true, opts)
+}
+
+// consulEntry is an entry returned by Consul's KV API.
+type consulEntry struct {
+	Key   string
+	Value []byte // base64-encoded in the JSON representation
+}
+
+// consulClient reads keys from Consul's KV API.
+type consulClient struct {
+	endpoint string
+	settings ConsulSettings
+	prefix   string
+
+	m     sync.Mutex
+	index uint64
+	idle  bool // true if the previous blocking query returned without a change
+}
+
+// consulRetryDelay is the delay before a blocking query is made after the
+// previous one returned without the index changing, such as when the agent
+// does not support blocking queries for the endpoint.
+const consulRetryDelay = 1 * time.Second
+
+// newConsulClient returns a client that reads the keys that begin with prefix.
+func newConsulClient(s ConsulSettings, prefix string) (*consulClient, error) {
+	if s.Address == "" {
+		s.Address = "http://127.0.0.1:8500"
+	} else if !strings.Contains(s.Address, "://") {
+		s.Address = "http://" + s.Address
+	}
+
+	if s.Client == nil {
+		s.Client = http.DefaultClient
+	}
+
+	if s.WaitTime == 0 {
+		s.WaitTime = 5 * time.Minute
+	}
+
+	u, err := url.Parse(s.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid consul address: %w", err)
+	}
+
+	u = u.JoinPath("v1", "kv", prefix)
+
+	q := u.Query()
+	q.Set("recurse", "true")
+	if s.Datacenter != "" {
+		q.Set("dc", s.Datacenter)
+	}
+	u.RawQuery = q.Encode()
+
+	return &consulClient{
+		endpoint: u.String(),
+		settings: s,
+		prefix:   prefix,
+	}, nil
+}
+
+// load returns a bucket containing the current keys.
+//
+// If block is true, it blocks until the keys change, the wait time elapses,
+// or ctx is canceled.
+func (c *consulClient) load(ctx context.Context, block bool) (Bucket, error) {
+	c.m.Lock()
+	index, idle := c.index, c.idle
+	c.m.Unlock()
+
+	endpoint := c.endpoint
+
+	if block {
+		// Rate-limit blocking queries that return immediately, as recommended
+		// by Consul's documentation.
+		if idle {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(consulRetryDelay):
+			}
+		}
+
+		// Consul's documentation recommends that the index is always at least
+		// 1, as an index of 0 returns immediately.
+		if index == 0 {
+			index = 1
+		}
+
+		endpoint += fmt.Sprintf(
+			"&index=%d&wait=%dms",
+			index,
+			c.settings.WaitTime.Milliseconds(),
+		)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.settings.Token != "" {
+		req.Header.Set("X-Consul-Token", c.settings.Token)
+	}
+
+	res, err := c.settings.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var entries []consulEntry
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(res.Body).Decode(&entries); err != nil {
+			return nil, fmt.Errorf("unable to decode consul response: %w", err)
+		}
+	case http.StatusNotFound:
+		// There are no keys with the prefix.
+	default:
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf(
+			"consul responded with %s: %s",
+			res.Status,
+			strings.TrimSpace(string(body)),
+		)
+	}
+
+	c.updateIndex(res.Header.Get("X-Consul-Index"), block, index)
+
+	m := Map{}
+
+	for _, e := range entries {
+		k := strings.TrimPrefix(e.Key, c.prefix)
+
+		if k == "" || strings.HasSuffix(k, "/") || len(e.Value) == 0 {
+			continue
+		}
+
+		m[k] = described(Bytes(e.Value), "consul key "+e.Key)
+	}
+
+	return m, nil
+}
+
+// updateIndex records the index returned by Consul, which is used by the next
+// blocking query.
+//
+// If block is true, prev is the index that was sent with the query.
+func (c *consulClient) updateIndex(h string, block bool, prev uint64) {
+	index, _ := strconv.ParseUint(h, 10, 64)
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.idle = block && index <= prev
+
+	// Consul's documentation recommends resetting the index if it goes
+	// backwards, such as when the agent's state is restored from a snapshot.
+	if index < c.index {
+		index = 0
+	}
+
+	c.index = index
+}
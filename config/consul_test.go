@@ -0,0 +1,281 @@
+package config_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// consulServer is a stand-in for Consul's KV API.
+type consulServer struct {
+	m       sync.Mutex
+	index   uint64
+	values  map[string]string
+	changed chan struct{}
+
+	token     string
+	omitIndex bool
+	requests  []*http.Request
+}
+
+func newConsulServer(token string) *consulServer {
+	return &consulServer{
+		index:   1,
+		values:  map[string]string{},
+		changed: make(chan struct{}),
+		token:   token,
+	}
+}
+
+// set sets the value of a key and wakes any blocked queries.
+func (s *consulServer) set(k, v string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.values[k] = v
+	s.index++
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// lastRequest returns the most recent request.
+func (s *consulServer) lastRequest() *http.Request {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.requests[len(s.requests)-1]
+}
+
+// requestCount returns the number of requests made.
+func (s *consulServer) requestCount() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return len(s.requests)
+}
+
+func (s *consulServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.m.Lock()
+	s.requests = append(s.requests, r)
+
+	if r.Header.Get("X-Consul-Token") != s.token {
+		s.m.Unlock()
+		http.Error(w, "Permission denied", http.StatusForbidden)
+		return
+	}
+
+	if x := r.URL.Query().Get("index"); x != "" {
+		index, _ := strconv.ParseUint(x, 10, 64)
+		wait, _ := time.ParseDuration(r.URL.Query().Get("wait"))
+
+		if index == s.index {
+			changed := s.changed
+			s.m.Unlock()
+
+			select {
+			case <-changed:
+			case <-time.After(wait):
+			case <-r.Context().Done():
+			}
+
+			s.m.Lock()
+		}
+	}
+
+	defer s.m.Unlock()
+
+	prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+
+	type entry struct {
+		Key   string
+		Value []byte
+	}
+
+	var entries []entry
+	for k, v := range s.values {
+		if strings.HasPrefix(k, prefix) {
+			e := entry{Key: k}
+			if v != "" {
+				e.Value = []byte(v)
+			}
+			entries = append(entries, e)
+		}
+	}
+
+	if !s.omitIndex {
+		w.Header().Set("X-Consul-Index", strconv.FormatUint(s.index, 10))
+	}
+
+	if len(entries) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	json.NewEncoder(w).Encode(entries)
+}
+
+var _ = Describe("func ConsulKV()", func() {
+	var (
+		consul   *consulServer
+		server   *httptest.Server
+		settings ConsulSettings
+	)
+
+	BeforeEach(func() {
+		consul = newConsulServer("<token>")
+		consul.set("shared/HTTP_TIMEOUT", "5s")
+		consul.set("shared/folder/", "")
+		consul.set("shared/EMPTY", "")
+		consul.set("other/KEY", "<other>")
+
+		server = httptest.NewServer(consul)
+
+		settings = ConsulSettings{
+			Address:    server.URL,
+			Token:      "<token>",
+			Datacenter: "<dc>",
+			WaitTime:   time.Second,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("returns a bucket containing the keys with the prefix", func() {
+		bucket, err := ConsulKV(settings, "shared/")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer bucket.Close()
+
+		Expect(AsDuration(bucket, "HTTP_TIMEOUT")).To(Equal(5 * time.Second))
+		Expect(bucket.Get("HTTP_TIMEOUT").Source()).To(Equal("consul key shared/HTTP_TIMEOUT"))
+
+		var keys []string
+		bucket.Each(func(k string, _ Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		Expect(keys).To(ConsistOf("HTTP_TIMEOUT"))
+	})
+
+	It("sends the ACL token and datacenter", func() {
+		bucket, err := ConsulKV(settings, "shared/")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer bucket.Close()
+
+		r := consul.lastRequest()
+		Expect(r.Header.Get("X-Consul-Token")).To(Equal("<token>"))
+		Expect(r.URL.Query().Get("dc")).To(Equal("<dc>"))
+		Expect(r.URL.Query().Get("recurse")).To(Equal("true"))
+	})
+
+	It("returns an empty bucket if there are no keys with the prefix", func() {
+		bucket, err := ConsulKV(settings, "undefined/")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer bucket.Close()
+
+		Expect(bucket.Each(func(string, Value) bool {
+			return false
+		})).To(BeTrue())
+	})
+
+	It("returns an error if the keys can not be read", func() {
+		settings.Token = "<invalid>"
+
+		_, err := ConsulKV(settings, "shared/")
+		Expect(err).To(MatchError("consul responded with 403 Forbidden: Permission denied"))
+	})
+
+	It("applies changes using blocking queries", func() {
+		bucket, err := ConsulKV(settings, "shared/", WatchInterval(time.Hour))
+		Expect(err).ShouldNot(HaveOccurred())
+		defer bucket.Close()
+
+		var (
+			m       sync.Mutex
+			changes []string
+		)
+
+		cancel := bucket.Subscribe([]string{"HTTP_TIMEOUT"}, func(old, new Value) {
+			m.Lock()
+			defer m.Unlock()
+			changes = append(changes, old.String()+" -> "+new.String())
+		})
+		defer cancel()
+
+		consul.set("shared/HTTP_TIMEOUT", "10s")
+
+		// The watch interval is an hour, so the change can only have been
+		// detected by a blocking query.
+		Eventually(func() string {
+			return bucket.Get("HTTP_TIMEOUT").String()
+		}).Should(Equal("10s"))
+
+		Eventually(func() []string {
+			m.Lock()
+			defer m.Unlock()
+			return changes
+		}).Should(Equal([]string{"5s -> 10s"}))
+
+		Eventually(func() string {
+			return consul.lastRequest().URL.Query().Get("wait")
+		}).Should(Equal("1000ms"))
+	})
+
+	It("interrupts a blocking query when the bucket is reloaded", func() {
+		settings.WaitTime = time.Minute
+
+		bucket, err := ConsulKV(settings, "shared/", WatchInterval(time.Hour))
+		Expect(err).ShouldNot(HaveOccurred())
+		defer bucket.Close()
+
+		Eventually(func() string {
+			return consul.lastRequest().URL.Query().Get("index")
+		}).ShouldNot(BeEmpty())
+
+		start := time.Now()
+		err = bucket.Reload()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+
+		consul.set("shared/HTTP_TIMEOUT", "10s")
+
+		Eventually(func() string {
+			return bucket.Get("HTTP_TIMEOUT").String()
+		}).Should(Equal("10s"))
+	})
+
+	It("rate-limits blocking queries if the index is not returned", func() {
+		consul.omitIndex = true
+
+		bucket, err := ConsulKV(settings, "shared/")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer bucket.Close()
+
+		Eventually(consul.requestCount).Should(BeNumerically(">=", 2))
+		Consistently(consul.requestCount, 500*time.Millisecond).Should(BeNumerically("<=", 3))
+
+		Expect(consul.lastRequest().URL.Query().Get("index")).To(Equal("1"))
+	})
+
+	It("stops watching when the bucket is closed", func() {
+		bucket, err := ConsulKV(settings, "shared/")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			bucket.Close()
+		}()
+
+		Eventually(done).Should(BeClosed())
+	})
+})
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Directory returns a Bucket that produces configuration values from the files
+// in the directory at path p.
+//
+// Each regular file in the directory is a key, the value of which is the
+// content of the file. Symbolic links to regular files are followed, such that
+// the directory may be a Kubernetes ConfigMap or Secret volume, in which each
+// key is a symbolic link into the "..data" directory.
+//
+// Entries with names that begin with a dot are ignored, as are
+// sub-directories.
+//
+// The files are read each time a value is consumed, and so updates to the
+// directory are visible without creating a new bucket. Value.AsPath() returns
+// the path to the file within the directory.
+//
+// It returns an error if p is not a directory.
+func Directory(p string) (Bucket, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", p)
+	}
+
+	return directory{p}, nil
+}
+
+// directory is an implementation of Bucket that sources values from files in
+// a directory.
+type directory struct {
+	path string
+}
+
+// Get returns the value associated with the given key.
+//
+// If they key is not defined, it returns a zero-value.
+func (d directory) Get(k string) Value {
+	if !isDirectoryKey(k) {
+		return Value{}
+	}
+
+	p := filepath.Join(d.path, k)
+
+	info, err := os.Stat(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Value{}
+		}
+
+		return fail(err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return Value{}
+	}
+
+	return File(p)
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// If the key is not defined, it returns a value with the content of v.
+func (d directory) GetDefault(k string, v string) Value {
+	x := d.Get(k)
+
+	if x.IsZero() {
+		return String(v)
+	}
+
+	return x
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+//
+// If the directory can not be read, such as when it has been removed, the
+// bucket is empty.
+func (d directory) Each(fn EachFunc) bool {
+	ok, _ := d.tryEach(fn)
+	return ok
+}
+
+// tryEach calls fn for each key/value pair in the bucket, as per Each().
+//
+// It returns an error if the directory can not be read.
+func (d directory) tryEach(fn EachFunc) (bool, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return true, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		if x := d.Get(k); !x.IsZero() {
+			if !fn(k, x) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// isDirectoryKey returns true if k is a valid key for a directory bucket.
+func isDirectoryKey(k string) bool {
+	return k != "" &&
+		!strings.HasPrefix(k, ".") &&
+		!strings.ContainsAny(k, `/\`)
+}
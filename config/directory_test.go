@@ -0,0 +1,216 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Directory()", func() {
+	var (
+		dir    string
+		bucket Bucket
+	)
+
+	// write creates a file within dir.
+	write := func(name, content string) {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	// replaceWithFile replaces dir with a regular file, such that it can no
+	// longer be read as a directory.
+	replaceWithFile := func() {
+		err := os.RemoveAll(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = os.WriteFile(dir, []byte("<not a directory>"), 0600)
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// Mimic the layout of a Kubernetes ConfigMap volume, in which each key
+		// is a symlink into the "..data" directory, which is itself a symlink
+		// to a timestamped directory.
+		err = os.Mkdir(filepath.Join(dir, "..2022_01_01"), 0700)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		write("..2022_01_01/KEY_1", "<value-1>")
+		write("..2022_01_01/KEY_2", "<value-2>")
+
+		err = os.Symlink("..2022_01_01", filepath.Join(dir, "..data"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = os.Symlink("..data/KEY_1", filepath.Join(dir, "KEY_1"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = os.Symlink("..data/KEY_2", filepath.Join(dir, "KEY_2"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// Add some entries that should be ignored.
+		write(".hidden", "<hidden>")
+		err = os.Mkdir(filepath.Join(dir, "subdir"), 0700)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		bucket, err = Directory(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("returns an error if the directory does not exist", func() {
+		_, err := Directory(filepath.Join(dir, "does-not-exist"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("returns an error if the path is not a directory", func() {
+		_, err := Directory(filepath.Join(dir, "KEY_1"))
+		Expect(err).To(MatchError(filepath.Join(dir, "KEY_1") + " is not a directory"))
+	})
+
+	Describe("func Get()", func() {
+		It("returns the content of the file", func() {
+			v := bucket.Get("KEY_1")
+			Expect(v.String()).To(Equal("<value-1>"))
+		})
+
+		It("returns the zero-value if the file does not exist", func() {
+			v := bucket.Get("UNDEFINED")
+			Expect(v.IsZero()).To(BeTrue())
+		})
+
+		It("returns the zero-value for hidden entries, directories and paths", func() {
+			for _, k := range []string{".hidden", "..data", "subdir", "..data/KEY_1", ""} {
+				v := bucket.Get(k)
+				Expect(v.IsZero()).To(BeTrue(), k)
+			}
+		})
+
+		It("reflects atomic updates to the ..data symlink", func() {
+			err := os.Mkdir(filepath.Join(dir, "..2022_01_02"), 0700)
+			Expect(err).ShouldNot(HaveOccurred())
+			write("..2022_01_02/KEY_1", "<updated>")
+
+			tmp := filepath.Join(dir, "..data_tmp")
+			err = os.Symlink("..2022_01_02", tmp)
+			Expect(err).ShouldNot(HaveOccurred())
+			err = os.Rename(tmp, filepath.Join(dir, "..data"))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			v := bucket.Get("KEY_1")
+			Expect(v.String()).To(Equal("<updated>"))
+		})
+
+		It("returns a value with a path within the mounted directory", func() {
+			v := bucket.Get("KEY_1")
+
+			p, c, err := v.AsPath()
+			Expect(err).ShouldNot(HaveOccurred())
+			defer c.Close()
+
+			Expect(p).To(Equal(filepath.Join(dir, "KEY_1")))
+		})
+	})
+
+	Describe("func GetDefault()", func() {
+		It("returns the content of the file", func() {
+			v := bucket.GetDefault("KEY_2", "<default>")
+			Expect(v.String()).To(Equal("<value-2>"))
+		})
+
+		It("returns the default value if the file does not exist", func() {
+			v := bucket.GetDefault("UNDEFINED", "<default>")
+			Expect(v.String()).To(Equal("<default>"))
+		})
+	})
+
+	Describe("func Each()", func() {
+		It("invokes the function for each file in the directory", func() {
+			calls := map[string]string{}
+
+			fn := func(k string, v Value) bool {
+				calls[k] = v.String()
+				return true
+			}
+
+			Expect(bucket.Each(fn)).To(BeTrue())
+			Expect(calls).To(Equal(map[string]string{
+				"KEY_1": "<value-1>",
+				"KEY_2": "<value-2>",
+			}))
+		})
+
+		It("stops iterating if the function returns false", func() {
+			count := 0
+
+			fn := func(k string, v Value) bool {
+				count++
+				return false
+			}
+
+			Expect(bucket.Each(fn)).To(BeFalse())
+			Expect(count).To(Equal(1))
+		})
+
+		It("does not invoke the function if the directory has been removed", func() {
+			err := os.RemoveAll(dir)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			fn := func(k string, v Value) bool {
+				Fail("unexpected call")
+				return true
+			}
+
+			Expect(bucket.Each(fn)).To(BeTrue())
+		})
+
+		It("does not invoke the function if the directory can not be read", func() {
+			replaceWithFile()
+
+			fn := func(k string, v Value) bool {
+				Fail("unexpected call")
+				return true
+			}
+
+			Expect(bucket.Each(fn)).To(BeTrue())
+		})
+	})
+
+	When("the directory can not be read", func() {
+		BeforeEach(func() {
+			replaceWithFile()
+		})
+
+		It("contributes no keys to a layered bucket", func() {
+			var keys []string
+			Layered(Map{"KEY_3": String("<value-3>")}, bucket).Each(
+				func(k string, v Value) bool {
+					keys = append(keys, k)
+					return true
+				},
+			)
+
+			Expect(keys).To(Equal([]string{"KEY_3"}))
+		})
+
+		It("does not report any unused keys", func() {
+			Expect(FindUnused(bucket, "", []string{"KEY_1"})).To(BeEmpty())
+			Expect(FindUnused(Layered(bucket), "", []string{"KEY_1"})).To(BeEmpty())
+		})
+
+		It("reports the error when a value is read", func() {
+			_, err := TryAsString(bucket, "KEY_1")
+			Expect(err).To(BeAssignableToTypeOf(Unreadable{}))
+			Expect(err.(Unreadable).Key).To(Equal("KEY_1"))
+		})
+	})
+})
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown returns a Markdown table that documents the keys in the spec.
+//
+// The default values of sensitive keys are not disclosed.
+//
+// The keys are listed in the order they were declared, such that the output
+// is deterministic.
+func (s *Spec) Markdown() string {
+	return renderMarkdown(s.Keys())
+}
+
+// renderMarkdown returns a Markdown table that documents the given keys.
+func renderMarkdown(keys []KeySpec) string {
+	var w strings.Builder
+
+	w.WriteString("| Name | Type | Default | Description |\n")
+	w.WriteString("| ---- | ---- | ------- | ----------- |\n")
+
+	for _, k := range keys {
+		def := "*required*"
+		if k.HasDefault {
+			def = "`" + documentedDefault(k) + "`"
+		} else if !k.Required {
+			def = "*optional*"
+		}
+
+		var lines []string
+		if k.Description != "" {
+			lines = append(lines, k.Description)
+		}
+		lines = append(lines, keyNotes(k)...)
+
+		fmt.Fprintf(
+			&w,
+			"| `%s` | `%s` | %s | %s |\n",
+			k.Name,
+			k.Type,
+			escapeMarkdownCell(def),
+			escapeMarkdownCell(strings.Join(lines, "<br>")),
+		)
+	}
+
+	return w.String()
+}
+
+// Usage returns plain-text documentation for the keys in the spec, suitable
+// for inclusion in the output of a "--help" flag.
+//
+// The default values of sensitive keys are not disclosed.
+//
+// The keys are listed in the order they were declared, such that the output
+// is deterministic.
+func (s *Spec) Usage() string {
+	var w strings.Builder
+
+	w.WriteString("ENVIRONMENT VARIABLES:\n")
+
+	for _, k := range s.Keys() {
+		attrs := []string{k.Type}
+		if k.HasDefault {
+			attrs = append(attrs, "default: "+documentedDefault(k))
+		} else if k.Required {
+			attrs = append(attrs, "required")
+		} else {
+			attrs = append(attrs, "optional")
+		}
+
+		fmt.Fprintf(&w, "\n  %s (%s)\n", k.Name, strings.Join(attrs, ", "))
+
+		if k.Description != "" {
+			fmt.Fprintf(&w, "      %s\n", k.Description)
+		}
+
+		for _, n := range keyNotes(k) {
+			fmt.Fprintf(&w, "      %s\n", n)
+		}
+	}
+
+	return w.String()
+}
+
+// DotEnvExample returns the content of an example "dotenv" file that
+// documents the keys in the spec.
+//
+// Required keys are assigned an empty value, which must be replaced. Keys that
+// are optional or have a default value are commented out. The default values
+// of sensitive keys are not disclosed.
+//
+// The keys are listed in the order they were declared, such that the output
+// is deterministic.
+func (s *Spec) DotEnvExample() string {
+	var w strings.Builder
+
+	for i, k := range s.Keys() {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+
+		if k.Description != "" {
+			fmt.Fprintf(&w, "# %s\n", k.Description)
+		}
+
+		attrs := k.Type
+		if k.Required {
+			attrs += ", required"
+		}
+		fmt.Fprintf(&w, "# (%s)\n", attrs)
+
+		for _, n := range keyNotes(k) {
+			fmt.Fprintf(&w, "# %s\n", n)
+		}
+
+		if k.Required {
+			fmt.Fprintf(&w, "%s=\n", k.Name)
+		} else {
+			fmt.Fprintf(&w, "# %s=%s\n", k.Name, quoteDotEnv(documentedDefault(k)))
+		}
+	}
+
+	return w.String()
+}
+
+// documentedDefault returns the representation of k's default value that is
+// used in documentation.
+//
+// The default value of a sensitive key is not disclosed.
+func documentedDefault(k KeySpec) string {
+	if k.Sensitive && k.HasDefault {
+		return sensitivePlaceholder
+	}
+
+	return k.Default
+}
+
+// keyNotes returns additional sentences that describe the permitted values of
+// k.
+func keyNotes(k KeySpec) []string {
+	var notes []string
+
+	if k.Min != "" || k.Max != "" {
+		notes = append(
+			notes,
+			fmt.Sprintf("Must be between %s and %s (inclusive).", k.Min, k.Max),
+		)
+	}
+
+	if k.Sensitive {
+		notes = append(notes, "The value is sensitive.")
+	}
+
+	if k.Sensitive || k.Type == "[]byte" {
+		notes = append(
+			notes,
+			fmt.Sprintf(
+				"Set %s%s to %q, %q, %q or %q to specify how the value is encoded.",
+				k.Name,
+				suffix,
+				sourceStringPlain,
+				sourceStringHex,
+				sourceStringBase64,
+				sourceFile,
+			),
+		)
+	}
+
+	return notes
+}
+
+// escapeMarkdownCell escapes s for use within a Markdown table cell.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// quoteDotEnv returns s quoted for use as a value in a dotenv file, if
+// necessary.
+func quoteDotEnv(s string) string {
+	if s == "" || !strings.ContainsAny(s, " \t\n#'\"\\") {
+		return s
+	}
+
+	if !strings.Contains(s, "'") && !strings.Contains(s, "\n") {
+		return "'" + s + "'"
+	}
+
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"$", `\$`,
+	)
+
+	return `"` + r.Replace(s) + `"`
+}
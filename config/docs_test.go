@@ -0,0 +1,97 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("type Spec (documentation)", func() {
+	var spec *Spec
+
+	// golden returns the content of a file in the testdata directory.
+	golden := func(name string) string {
+		buf, err := os.ReadFile(filepath.Join("testdata", name))
+		Expect(err).ShouldNot(HaveOccurred())
+		return string(buf)
+	}
+
+	BeforeEach(func() {
+		spec = &Spec{}
+
+		Declare(
+			spec,
+			"HTTP_TIMEOUT",
+			"the timeout for HTTP requests",
+			WithDefault(30*time.Second),
+			WithRange(1*time.Second, 5*time.Minute),
+		)
+
+		Declare(
+			spec,
+			"WORKERS",
+			"the number of workers",
+			WithRange(1, 100),
+		)
+
+		Declare(
+			spec,
+			"GREETING",
+			"the greeting | salutation shown to users",
+			WithDefault("hello, world"),
+		)
+
+		Declare[string](
+			spec,
+			"DB_PASSWORD",
+			"the database password",
+		).Sensitive()
+
+		Declare(
+			spec,
+			"API_KEY",
+			"the key used to authenticate with the API",
+			WithDefault("<development-key>"),
+		).Sensitive()
+
+		Declare[[]byte](
+			spec,
+			"SIGNING_KEY",
+			"the key used to sign tokens",
+		).Optional()
+	})
+
+	Describe("func Markdown()", func() {
+		It("returns a Markdown table", func() {
+			Expect(spec.Markdown()).To(Equal(golden("spec.md")))
+		})
+	})
+
+	Describe("func Usage()", func() {
+		It("returns plain-text documentation", func() {
+			Expect(spec.Usage()).To(Equal(golden("spec.txt")))
+		})
+	})
+
+	Describe("func DotEnvExample()", func() {
+		It("returns an example dotenv file", func() {
+			Expect(spec.DotEnvExample()).To(Equal(golden("spec.env")))
+		})
+
+		It("returns a well-formed dotenv file that produces the default values", func() {
+			b, err := DotEnvReader(strings.NewReader(spec.DotEnvExample()))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			errs := spec.Validate(b)
+			Expect(errs).To(Equal([]KeyError{
+				NotDefined{Key: "WORKERS"},
+				NotDefined{Key: "DB_PASSWORD"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DocumentOption is an option that changes how the keys of a structured
+// configuration document, such as a JSON or YAML file, are mapped to bucket
+// keys.
+type DocumentOption func(*documentOptions)
+
+// documentOptions is the set of options applied when flattening a document.
+type documentOptions struct {
+	sep     string
+	keyCase func(string) string
+}
+
+// DocumentKeySeparator returns an option that sets the separator placed between
+// the names of nested keys.
+//
+// The default separator is an underscore, such that the "timeout" key within
+// the "http" object is mapped to the HTTP_TIMEOUT bucket key.
+func DocumentKeySeparator(sep string) DocumentOption {
+	return func(o *documentOptions) {
+		o.sep = sep
+	}
+}
+
+// DocumentKeyCase returns an option that sets the function used to map the
+// name of each key within the document to the corresponding part of the bucket
+// key.
+//
+// By default names are converted to uppercase, and any character that is not a
+// letter, digit or underscore is replaced with an underscore.
+func DocumentKeyCase(fn func(string) string) DocumentOption {
+	return func(o *documentOptions) {
+		o.keyCase = fn
+	}
+}
+
+// defaultDocumentKeyCase is the default function used to map the names of keys
+// within a document to bucket keys.
+func defaultDocumentKeyCase(s string) string {
+	return strings.Map(
+		func(r rune) rune {
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToUpper(r)
+			}
+			return '_'
+		},
+		s,
+	)
+}
+
+// docNode is a node within a structured configuration document.
+type docNode struct {
+	// Line is the line on which the node appears in the document.
+	Line int
+
+	// Kind is the type of node.
+	Kind docNodeKind
+
+	// Scalar is the content of a docScalar node.
+	Scalar string
+
+	// Members is the content of a docObject node, in the order they appear in
+	// the document.
+	Members []docMember
+
+	// Elements is the content of a docArray node.
+	Elements []docNode
+}
+
+// docNodeKind is an enumeration of the types of docNode.
+type docNodeKind int
+
+const (
+	docNull docNodeKind = iota
+	docScalar
+	docObject
+	docArray
+)
+
+// docMember is a key/value pair within a docObject node.
+type docMember struct {
+	Name  string
+	Value docNode
+}
+
+// flattenDocument returns a bucket containing the values in the given
+// document.
+//
+// root must be a docObject node. p is the path to the document, used for error
+// reporting and to describe the source of each value.
+func flattenDocument(p string, root docNode, opts []DocumentOption) (Bucket, error) {
+	f := &flattener{
+		path: p,
+		opts: documentOptions{
+			sep:     "_",
+			keyCase: defaultDocumentKeyCase,
+		},
+		values: Map{},
+		lines:  map[string]int{},
+	}
+
+	for _, opt := range opts {
+		opt(&f.opts)
+	}
+
+	if err := f.flattenObject("", root); err != nil {
+		return nil, err
+	}
+
+	return f.values, nil
+}
+
+// flattener maps the nodes of a document to bucket keys.
+type flattener struct {
+	path   string
+	opts   documentOptions
+	values Map
+	lines  map[string]int
+}
+
+// flattenObject adds the members of n to the bucket, with keys beginning with
+// prefix.
+func (f *flattener) flattenObject(prefix string, n docNode) error {
+	for _, m := range n.Members {
+		k := f.opts.keyCase(m.Name)
+		if prefix != "" {
+			k = prefix + f.opts.sep + k
+		}
+
+		if err := f.flatten(k, m.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flatten adds n to the bucket with the key k.
+//
+// Arrays of scalars are represented as a single value using the syntax
+// expected by AsStringSlice() and the other list functions. Arrays containing
+// objects or other arrays are represented as one key per element, with the
+// element's index appended to k.
+func (f *flattener) flatten(k string, n docNode) error {
+	switch n.Kind {
+	case docScalar:
+		return f.set(k, n.Line, n.Scalar)
+
+	case docObject:
+		return f.flattenObject(k, n)
+
+	case docArray:
+		if !isScalarArray(n) {
+			for i, e := range n.Elements {
+				if err := f.flatten(k+f.opts.sep+strconv.Itoa(i), e); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		if len(n.Elements) == 0 {
+			return nil
+		}
+
+		elements := make([]string, len(n.Elements))
+		for i, e := range n.Elements {
+			elements[i] = quoteElement(e.Scalar)
+		}
+
+		return f.set(k, n.Line, strings.Join(elements, ","))
+	}
+
+	return nil
+}
+
+// set adds a value to the bucket.
+func (f *flattener) set(k string, line int, v string) error {
+	if prev, ok := f.lines[k]; ok {
+		return SyntaxError{
+			Path:        f.path,
+			Line:        line,
+			Explanation: fmt.Sprintf("%s is already defined on line %d", k, prev),
+		}
+	}
+
+	f.lines[k] = line
+
+	if v == "" {
+		return nil
+	}
+
+	origin := fmt.Sprintf("line %d", line)
+	if f.path != "" {
+		origin += " of " + f.path
+	}
+
+	f.values[k] = described(String(v), origin)
+
+	return nil
+}
+
+// isScalarArray returns true if n is an array that contains only scalar values.
+func isScalarArray(n docNode) bool {
+	for _, e := range n.Elements {
+		if e.Kind != docScalar {
+			return false
+		}
+	}
+
+	return true
+}
+
+// quoteElement returns s quoted for use as a list element, if necessary.
+//
+// See AsStringSlice() for a description of the list syntax.
+func quoteElement(s string) string {
+	if s != "" &&
+		s == strings.TrimSpace(s) &&
+		!strings.ContainsAny(s, `,"`) {
+		return s
+	}
+
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+	)
+
+	return `"` + r.Replace(s) + `"`
+}
+
+// lineAt returns the (1-based) line number of the given offset within buf.
+func lineAt(buf []byte, offset int64) int {
+	if offset > int64(len(buf)) {
+		offset = int64(len(buf))
+	}
+
+	return 1 + bytes.Count(buf[:offset], []byte("\n"))
+}
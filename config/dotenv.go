@@ -0,0 +1,357 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DotEnv returns a Bucket that produces configuration values from the
+// "dotenv" file at path p.
+//
+// Each line of the file is either blank, a comment beginning with "#", or a
+// variable assignment of the form KEY=VALUE, optionally preceded by "export".
+//
+// VALUE may be:
+//
+// ● unquoted, in which case leading and trailing whitespace is removed, and
+// any text following a "#" that is preceded by whitespace is treated as a
+// comment
+//
+// ● enclosed in single quotes, in which case it is used literally
+//
+// ● enclosed in double quotes, in which case the escape sequences \n, \r, \t,
+// \", \', \$ and \\ are supported
+//
+// Quoted values may span multiple lines.
+//
+// As with Environment(), for any given variable K the variable K__DATASOURCE
+// indicates how the content of K should be interpreted.
+//
+// It returns a SyntaxError if the file is not well-formed.
+func DotEnv(p string) (Bucket, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseDotEnv(p, f)
+}
+
+// DotEnvReader returns a Bucket that produces configuration values from the
+// "dotenv" content in r.
+//
+// See DotEnv() for a description of the supported syntax.
+func DotEnvReader(r io.Reader) (Bucket, error) {
+	return parseDotEnv("", r)
+}
+
+// parseDotEnv parses the dotenv content in r and returns a bucket containing
+// its variables.
+//
+// p is the path to the file, used only for error reporting.
+func parseDotEnv(p string, r io.Reader) (Bucket, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, lines, err := (&dotenvParser{
+		path: p,
+		data: strings.ReplaceAll(string(buf), "\r\n", "\n"),
+		line: 1,
+	}).parse()
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := func(k string) string {
+		return vars[k]
+	}
+
+	m := Map{}
+
+	for k := range vars {
+		if isDataSource(k) {
+			continue
+		}
+
+		origin := fmt.Sprintf("line %d", lines[k])
+		if p != "" {
+			origin += " of " + p
+		}
+
+		m[k] = resolve(k, lookup, origin)
+	}
+
+	return m, nil
+}
+
+// dotenvParser parses the content of a dotenv file.
+type dotenvParser struct {
+	path string
+	data string
+	pos  int
+	line int
+}
+
+// parse returns the raw variables defined in the content, and the line number
+// on which each variable is assigned.
+func (p *dotenvParser) parse() (map[string]string, map[string]int, error) {
+	vars := map[string]string{}
+	lines := map[string]int{}
+
+	for {
+		p.skipBlank()
+
+		if p.eof() {
+			return vars, lines, nil
+		}
+
+		if p.peek() == '#' {
+			p.skipLine()
+			continue
+		}
+
+		line := p.line
+
+		k, v, err := p.parseAssignment()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		vars[k] = v
+		lines[k] = line
+	}
+}
+
+// parseAssignment parses a single KEY=VALUE assignment.
+func (p *dotenvParser) parseAssignment() (string, string, error) {
+	k := p.parseKey()
+
+	if k == "export" && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipSpace()
+		k = p.parseKey()
+	}
+
+	if k == "" {
+		return "", "", p.errorf("expected a variable name")
+	}
+
+	p.skipSpace()
+
+	if p.peek() != '=' {
+		return "", "", p.errorf("expected '=' after %s", k)
+	}
+
+	p.pos++
+	p.skipSpace()
+
+	var (
+		v   string
+		err error
+	)
+
+	switch p.peek() {
+	case '\'':
+		v, err = p.parseSingleQuoted()
+	case '"':
+		v, err = p.parseDoubleQuoted()
+	default:
+		return k, p.parseUnquoted(), nil
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	p.skipSpace()
+
+	switch p.peek() {
+	case 0, '\n':
+	case '#':
+		p.skipLine()
+	default:
+		return "", "", p.errorf("unexpected %q after closing quote", p.peek())
+	}
+
+	return k, v, nil
+}
+
+// parseKey parses a variable name.
+func (p *dotenvParser) parseKey() string {
+	start := p.pos
+
+	for !p.eof() {
+		c := p.peek()
+
+		if c == '_' ||
+			'a' <= c && c <= 'z' ||
+			'A' <= c && c <= 'Z' ||
+			'0' <= c && c <= '9' && p.pos > start {
+			p.pos++
+			continue
+		}
+
+		break
+	}
+
+	return p.data[start:p.pos]
+}
+
+// parseUnquoted parses an unquoted value, up to the end of the line or the
+// beginning of a comment.
+func (p *dotenvParser) parseUnquoted() string {
+	start := p.pos
+
+	for !p.eof() && p.peek() != '\n' {
+		if p.peek() == '#' && p.pos > start {
+			if c := p.data[p.pos-1]; c == ' ' || c == '\t' {
+				v := p.data[start:p.pos]
+				p.skipLine()
+				return strings.TrimSpace(v)
+			}
+		}
+
+		p.pos++
+	}
+
+	return strings.TrimSpace(p.data[start:p.pos])
+}
+
+// parseSingleQuoted parses a value enclosed in single quotes.
+func (p *dotenvParser) parseSingleQuoted() (string, error) {
+	line := p.line
+	p.pos++ // opening quote
+	start := p.pos
+
+	for !p.eof() {
+		switch p.peek() {
+		case '\'':
+			v := p.data[start:p.pos]
+			p.pos++ // closing quote
+			return v, nil
+		case '\n':
+			p.line++
+		}
+
+		p.pos++
+	}
+
+	return "", p.errorAt(line, "unterminated single-quoted value")
+}
+
+// parseDoubleQuoted parses a value enclosed in double quotes, interpreting
+// any escape sequences.
+func (p *dotenvParser) parseDoubleQuoted() (string, error) {
+	line := p.line
+	p.pos++ // opening quote
+
+	var v strings.Builder
+
+	for !p.eof() {
+		c := p.peek()
+		p.pos++
+
+		switch c {
+		case '"':
+			return v.String(), nil
+		case '\n':
+			p.line++
+		case '\\':
+			if p.eof() {
+				continue
+			}
+
+			e := p.peek()
+			p.pos++
+
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case '"', '\'', '$', '\\':
+				c = e
+			case '\n':
+				// a backslash at the end of a line is a line continuation
+				p.line++
+				continue
+			default:
+				return "", p.errorf("unrecognised escape sequence \\%c", e)
+			}
+		}
+
+		v.WriteByte(c)
+	}
+
+	return "", p.errorAt(line, "unterminated double-quoted value")
+}
+
+// skipBlank advances past any whitespace, including newlines.
+func (p *dotenvParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case '\n':
+			p.line++
+		case ' ', '\t', '\r':
+		default:
+			return
+		}
+
+		p.pos++
+	}
+}
+
+// skipSpace advances past any whitespace on the current line.
+func (p *dotenvParser) skipSpace() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// skipLine advances to the end of the current line, without consuming the
+// newline itself.
+func (p *dotenvParser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+// peek returns the byte at the current position, or zero at the end of the
+// content.
+func (p *dotenvParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.data[p.pos]
+}
+
+// eof returns true if the entire content has been consumed.
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+// errorf returns a SyntaxError for the current line.
+func (p *dotenvParser) errorf(f string, v ...interface{}) error {
+	return p.errorAt(p.line, f, v...)
+}
+
+// errorAt returns a SyntaxError for a specific line.
+func (p *dotenvParser) errorAt(line int, f string, v ...interface{}) error {
+	return SyntaxError{
+		Path:        p.path,
+		Line:        line,
+		Explanation: fmt.Sprintf(f, v...),
+	}
+}
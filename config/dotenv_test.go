@@ -0,0 +1,137 @@
+package config_test
+
+import (
+	"encoding/hex"
+	"strings"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func DotEnv()", func() {
+	It("returns a bucket containing the variables in the file", func() {
+		b, err := DotEnv("./testdata/example.env")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(b.Get("FOO").String()).To(Equal("<foo>"))
+		Expect(b.Get("BAR").String()).To(Equal("<bar>"))
+	})
+
+	It("returns an error if the file does not exist", func() {
+		_, err := DotEnv("./testdata/does-not-exist.env")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("includes the path in syntax errors", func() {
+		_, err := DotEnv("./testdata/example.json")
+		Expect(err).To(MatchError(
+			`syntax error in ./testdata/example.json on line 1: expected a variable name`,
+		))
+	})
+})
+
+var _ = Describe("func DotEnvReader()", func() {
+	parse := func(s string) Bucket {
+		b, err := DotEnvReader(strings.NewReader(s))
+		Expect(err).ShouldNot(HaveOccurred())
+		return b
+	}
+
+	DescribeTable(
+		"it parses values",
+		func(content, expect string) {
+			b := parse(content)
+			Expect(b.Get("KEY").String()).To(Equal(expect))
+		},
+		Entry("unquoted", "KEY=value", "value"),
+		Entry("unquoted with surrounding whitespace", "KEY =  value  ", "value"),
+		Entry("unquoted with inline comment", "KEY=value # comment", "value"),
+		Entry("unquoted containing a hash", "KEY=val#ue", "val#ue"),
+		Entry("unquoted with CRLF line ending", "KEY=value\r\nOTHER=x", "value"),
+		Entry("export prefix", "export KEY=value", "value"),
+		Entry("single-quoted", `KEY='  value # not a comment  '`, "  value # not a comment  "),
+		Entry("single-quoted without escapes", `KEY='a\nb'`, `a\nb`),
+		Entry("single-quoted spanning multiple lines", "KEY='a\nb'", "a\nb"),
+		Entry("double-quoted", `KEY="  value  "`, "  value  "),
+		Entry("double-quoted with inline comment", `KEY="value" # comment`, "value"),
+		Entry("double-quoted with escapes", `KEY="a\nb\tc\"d\\e\$f"`, "a\nb\tc\"d\\e$f"),
+		Entry("double-quoted spanning multiple lines", "KEY=\"a\nb\"", "a\nb"),
+		Entry("double-quoted with line continuation", "KEY=\"a\\\nb\"", "ab"),
+		Entry("last definition wins", "KEY=first\nKEY=second", "second"),
+	)
+
+	It("ignores comments and blank lines", func() {
+		b := parse("# comment\n\n   \nKEY=value\n  # indented comment\n")
+
+		var keys []string
+		b.Each(func(k string, _ Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+
+		Expect(keys).To(ConsistOf("KEY"))
+	})
+
+	It("treats empty values as undefined", func() {
+		b := parse("KEY=")
+		v := b.Get("KEY")
+		Expect(v.IsZero()).To(BeTrue())
+	})
+
+	It("treats a variable named 'export' as a regular variable", func() {
+		b := parse("export=value")
+		Expect(b.Get("export").String()).To(Equal("value"))
+	})
+
+	Context("data-source variables", func() {
+		It("decodes hex values", func() {
+			b := parse("KEY=" + hex.EncodeToString([]byte("<value>")) + "\nKEY__DATASOURCE=string:hex")
+			Expect(b.Get("KEY").Bytes()).To(Equal([]byte("<value>")))
+		})
+
+		It("decodes base64 values", func() {
+			b := parse("KEY=PHZhbHVlPg==\nKEY__DATASOURCE=string:base64")
+			Expect(b.Get("KEY").Bytes()).To(Equal([]byte("<value>")))
+		})
+
+		It("reads file values", func() {
+			b := parse("KEY=./testdata/example.json\nKEY__DATASOURCE=file")
+			Expect(b.Get("KEY").String()).To(Equal(`{"example_config": true}` + "\n"))
+		})
+
+		It("does not expose the data-source variables themselves", func() {
+			b := parse("KEY=value\nKEY__DATASOURCE=string:plain")
+
+			v := b.Get("KEY__DATASOURCE")
+			Expect(v.IsZero()).To(BeTrue())
+
+			b.Each(func(k string, _ Value) bool {
+				Expect(k).To(Equal("KEY"))
+				return true
+			})
+		})
+
+		It("returns a failing value if the data-source is unrecognised", func() {
+			b := parse("KEY=value\nKEY__DATASOURCE=<unknown>")
+
+			_, err := b.Get("KEY").AsString()
+			Expect(err).To(MatchError("unrecognised environment variable source type: <unknown>"))
+		})
+	})
+
+	DescribeTable(
+		"it returns a syntax error",
+		func(content, expect string) {
+			_, err := DotEnvReader(strings.NewReader(content))
+			Expect(err).To(MatchError(expect))
+		},
+		Entry("missing key", "\n=value", `syntax error on line 2: expected a variable name`),
+		Entry("missing equals", "A=1\nKEY value", `syntax error on line 2: expected '=' after KEY`),
+		Entry("trailing characters after quote", `KEY="value"x`, `syntax error on line 1: unexpected 'x' after closing quote`),
+		Entry("unterminated single quote", "\nKEY='value\n\n", `syntax error on line 2: unterminated single-quoted value`),
+		Entry("unterminated double quote", "\nKEY=\"value\n\n", `syntax error on line 2: unterminated double-quoted value`),
+		Entry("unrecognised escape sequence", "\n\nKEY=\"\\q\"", `syntax error on line 3: unrecognised escape sequence \q`),
+		Entry("line number after multi-line value", "KEY=\"a\nb\"\n!", `syntax error on line 3: expected a variable name`),
+	)
+})
@@ -11,7 +11,17 @@ import (
 //
 // Durations are specified using the syntax supported by time.ParseDuration.
 func AsDuration(b Bucket, k string) time.Duration {
-	return asDuration(b, k, math.MinInt64, math.MaxInt64)
+	return asDuration(b, k, accessor{"AsDuration", false}, math.MinInt64, math.MaxInt64)
+}
+
+// TryAsDuration returns the time.Duration representation of the value
+// associated with k, or an error if unable to do so.
+//
+// Durations are specified using the syntax supported by time.ParseDuration.
+func TryAsDuration(b Bucket, k string) (time.Duration, error) {
+	return try(func() time.Duration {
+		return asDuration(b, k, accessor{"TryAsDuration", false}, math.MinInt64, math.MaxInt64)
+	})
 }
 
 // AsDurationDefault returns the time.Duration representation of the value
@@ -19,7 +29,19 @@ func AsDuration(b Bucket, k string) time.Duration {
 //
 // Durations are specified using the syntax supported by time.ParseDuration.
 func AsDurationDefault(b Bucket, k string, v time.Duration) time.Duration {
-	return asDurationDefault(b, k, v, math.MinInt64, math.MaxInt64)
+	return asDurationDefault(b, k, accessor{"AsDurationDefault", false}, v, math.MinInt64, math.MaxInt64)
+}
+
+// TryAsDurationDefault returns the time.Duration representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// Durations are specified using the syntax supported by time.ParseDuration.
+//
+// It returns an error if the value is invalid.
+func TryAsDurationDefault(b Bucket, k string, v time.Duration) (time.Duration, error) {
+	return try(func() time.Duration {
+		return asDurationDefault(b, k, accessor{"TryAsDurationDefault", false}, v, math.MinInt64, math.MaxInt64)
+	})
 }
 
 // AsDurationBetween returns the time.Duration representation of the value
@@ -29,7 +51,19 @@ func AsDurationDefault(b Bucket, k string, v time.Duration) time.Duration {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsDurationBetween(b Bucket, k string, min, max time.Duration) time.Duration {
-	return asDuration(b, k, min, max)
+	return asDuration(b, k, accessor{"AsDurationBetween", true}, min, max)
+}
+
+// TryAsDurationBetween returns the time.Duration representation of the value
+// associated with k, or an error if unable to do so.
+//
+// Durations are specified using the syntax supported by time.ParseDuration.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsDurationBetween(b Bucket, k string, min, max time.Duration) (time.Duration, error) {
+	return try(func() time.Duration {
+		return asDuration(b, k, accessor{"TryAsDurationBetween", true}, min, max)
+	})
 }
 
 // AsDurationDefaultBetween returns the time.Duration representation of the
@@ -39,39 +73,56 @@ func AsDurationBetween(b Bucket, k string, min, max time.Duration) time.Duration
 //
 // It panics if the value is not between min and max (inclusive).
 func AsDurationDefaultBetween(b Bucket, k string, v, min, max time.Duration) time.Duration {
-	return asDurationDefault(b, k, v, min, max)
+	return asDurationDefault(b, k, accessor{"AsDurationDefaultBetween", true}, v, min, max)
+}
+
+// TryAsDurationDefaultBetween returns the time.Duration representation of the
+// value associated with k, or the default value v if k is undefined.
+//
+// Durations are specified using the syntax supported by time.ParseDuration.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsDurationDefaultBetween(b Bucket, k string, v, min, max time.Duration) (time.Duration, error) {
+	return try(func() time.Duration {
+		return asDurationDefault(b, k, accessor{"TryAsDurationDefaultBetween", true}, v, min, max)
+	})
 }
 
 func tryAsDuration(
 	b Bucket,
 	k string,
+	a accessor,
 	min, max time.Duration,
 ) (time.Duration, bool) {
-	x := b.Get(k)
+	annotateRange(b, k, a, min, max)
+
+	x := access(b, k, a)
 
 	if x.IsZero() {
 		return 0, false
 	}
 
-	s := mustAsString(k, x)
+	s := mustAsString(qualify(b, k), x)
 	v, err := time.ParseDuration(s)
 	if err != nil {
 		panic(InvalidValue{
-			k,
-			s,
-			`expected a duration`,
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: `expected a duration`,
+			Source:      x.Source(),
 		})
 	}
 
 	if min > v || v > max {
 		panic(InvalidValue{
-			k,
-			s,
-			fmt.Sprintf(
+			Key:   qualify(b, k),
+			Value: s,
+			Explanation: fmt.Sprintf(
 				`expected a duration between %s and %s (inclusive)`,
 				min,
 				max,
 			),
+			Source: x.Source(),
 		})
 	}
 
@@ -81,25 +132,29 @@ func tryAsDuration(
 func asDuration(
 	b Bucket,
 	k string,
+	a accessor,
 	min, max time.Duration,
 ) time.Duration {
-	if v, ok := tryAsDuration(b, k, min, max); ok {
+	if v, ok := tryAsDuration(b, k, a, min, max); ok {
 		return v
 	}
 
-	panic(NotDefined{k})
+	panic(notDefined(b, k))
 }
 
 func asDurationDefault(
 	b Bucket,
 	k string,
+	a accessor,
 	d, min, max time.Duration,
 ) time.Duration {
+	annotateDefault(b, k, a, d)
+
 	if min > d || d > max {
 		panic(InvalidDefaultValue{
-			k,
-			d.String(),
-			fmt.Sprintf(
+			Key:          qualify(b, k),
+			DefaultValue: d.String(),
+			Explanation: fmt.Sprintf(
 				`expected a duration between %s and %s (inclusive)`,
 				min,
 				max,
@@ -107,7 +162,7 @@ func asDurationDefault(
 		})
 	}
 
-	if v, ok := tryAsDuration(b, k, min, max); ok {
+	if v, ok := tryAsDuration(b, k, a, min, max); ok {
 		return v
 	}
 
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AsEnum returns the value associated with k or panics if unable to do so.
+//
+// It panics if the value is not one of the allowed values.
+func AsEnum(b Bucket, k string, allowed ...string) string {
+	return Enum{Values: allowed}.as(b, k, accessor{"AsEnum", false})
+}
+
+// TryAsEnum returns the value associated with k, or an error if unable to do
+// so.
+//
+// It returns an error if the value is not one of the allowed values.
+func TryAsEnum(b Bucket, k string, allowed ...string) (string, error) {
+	return try(func() string {
+		return Enum{Values: allowed}.as(b, k, accessor{"TryAsEnum", false})
+	})
+}
+
+// AsEnumDefault returns the value associated with k, or the default value v if
+// k is undefined.
+//
+// It panics if the value is not one of the allowed values.
+func AsEnumDefault(b Bucket, k, v string, allowed ...string) string {
+	return Enum{Values: allowed}.asDefault(b, k, accessor{"AsEnumDefault", false}, v)
+}
+
+// TryAsEnumDefault returns the value associated with k, or the default value v
+// if k is undefined.
+//
+// It returns an error if the value is not one of the allowed values.
+func TryAsEnumDefault(b Bucket, k, v string, allowed ...string) (string, error) {
+	return try(func() string {
+		return Enum{Values: allowed}.asDefault(b, k, accessor{"TryAsEnumDefault", false}, v)
+	})
+}
+
+// Enum describes the set of values permitted for an enumerated key.
+type Enum struct {
+	// Values is the set of permitted values.
+	Values []string
+
+	// Aliases is a map of alternative spellings to the permitted value that
+	// they represent. The Enum methods panic if any alias refers to a value
+	// that is not in Values.
+	Aliases map[string]string
+
+	// CaseInsensitive indicates whether values and aliases are matched without
+	// regard to case.
+	CaseInsensitive bool
+}
+
+// As returns the value associated with k or panics if unable to do so.
+//
+// The returned value is always one of the values in e.Values, even if the
+// configuration value is an alias or has different letter case.
+//
+// It panics if the value is not permitted by e.
+func (e Enum) As(b Bucket, k string) string {
+	return e.as(b, k, accessor{"Enum.As", false})
+}
+
+// TryAs returns the value associated with k, or an error if unable to do so.
+//
+// See Enum.As() for more information.
+func (e Enum) TryAs(b Bucket, k string) (string, error) {
+	return try(func() string {
+		return e.as(b, k, accessor{"Enum.TryAs", false})
+	})
+}
+
+// AsDefault returns the value associated with k, or the default value v if k
+// is undefined.
+//
+// The returned value is always one of the values in e.Values, even if the
+// configuration value (or v) is an alias or has different letter case.
+//
+// It panics if the value is not permitted by e.
+func (e Enum) AsDefault(b Bucket, k, v string) string {
+	return e.asDefault(b, k, accessor{"Enum.AsDefault", false}, v)
+}
+
+// TryAsDefault returns the value associated with k, or the default value v if
+// k is undefined.
+//
+// It returns an error if the value is not permitted by e.
+func (e Enum) TryAsDefault(b Bucket, k, v string) (string, error) {
+	return try(func() string {
+		return e.asDefault(b, k, accessor{"Enum.TryAsDefault", false}, v)
+	})
+}
+
+func (e Enum) tryAs(
+	b Bucket,
+	k string,
+	a accessor,
+) (string, bool) {
+	return e.parse(b, k, access(b, k, a))
+}
+
+// parse returns the permitted value that x, the value associated with k,
+// represents.
+//
+// ok is false if x is the zero-value. It panics if x is not permitted by e.
+func (e Enum) parse(b Bucket, k string, x Value) (_ string, ok bool) {
+	if x.IsZero() {
+		return "", false
+	}
+
+	s := mustAsString(qualify(b, k), x)
+
+	if v, ok := e.match(s); ok {
+		return v, true
+	}
+
+	panic(InvalidValue{
+		Key:         qualify(b, k),
+		Value:       s,
+		Explanation: e.explanation(),
+		Source:      x.Source(),
+	})
+}
+
+func (e Enum) as(
+	b Bucket,
+	k string,
+	a accessor,
+) string {
+	if v, ok := e.tryAs(b, k, a); ok {
+		return v
+	}
+
+	panic(notDefined(b, k))
+}
+
+func (e Enum) asDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	v string,
+) string {
+	annotateDefault(b, k, a, v)
+
+	d, ok := e.match(v)
+	if !ok {
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: v,
+			Explanation:  e.explanation(),
+		})
+	}
+
+	if v, ok := e.tryAs(b, k, a); ok {
+		return v
+	}
+
+	return d
+}
+
+// validate panics if e is malformed.
+//
+// Every alias is checked, regardless of the value being matched, such that a
+// malformed enum is detected consistently.
+func (e Enum) validate() {
+	if len(e.Values) == 0 {
+		panic("enum must have at least one permitted value")
+	}
+
+	aliases := make([]string, 0, len(e.Aliases))
+	for alias := range e.Aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		if !e.permits(e.Aliases[alias]) {
+			panic(fmt.Sprintf(
+				"enum alias %#v refers to %#v, which is not a permitted value",
+				alias,
+				e.Aliases[alias],
+			))
+		}
+	}
+}
+
+// permits returns true if v is exactly one of the values in e.Values.
+func (e Enum) permits(v string) bool {
+	for _, x := range e.Values {
+		if x == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match returns the permitted value that s represents.
+//
+// It panics if e is malformed.
+func (e Enum) match(s string) (string, bool) {
+	e.validate()
+
+	equal := func(a, b string) bool {
+		if e.CaseInsensitive {
+			return strings.EqualFold(a, b)
+		}
+
+		return a == b
+	}
+
+	for _, v := range e.Values {
+		if equal(s, v) {
+			return v, true
+		}
+	}
+
+	for alias, target := range e.Aliases {
+		if equal(s, alias) {
+			return target, true
+		}
+	}
+
+	return "", false
+}
+
+// explanation returns the explanation used in errors that occur when a value
+// is not permitted by e.
+func (e Enum) explanation() string {
+	quoted := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		quoted[i] = fmt.Sprintf("%#v", v)
+	}
+
+	n := len(quoted)
+
+	if n == 1 {
+		return fmt.Sprintf("expected %s", quoted[0])
+	}
+
+	return fmt.Sprintf(
+		"expected one of %s or %s",
+		strings.Join(quoted[:n-1], ", "),
+		quoted[n-1],
+	)
+}
@@ -0,0 +1,158 @@
+package config_test
+
+import (
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func AsEnum()", func() {
+	It("returns the value", func() {
+		b := Map{"<key>": String("text")}
+
+		v := AsEnum(b, "<key>", "json", "text", "logfmt")
+		Expect(v).To(Equal("text"))
+	})
+
+	It("panics if the key is not defined", func() {
+		Expect(func() {
+			AsEnum(Map{}, "<key>", "json", "text")
+		}).To(PanicWith(NotDefined{Key: "<key>"}))
+	})
+
+	It("panics if the value is not one of the allowed values", func() {
+		b := Map{"<key>": String("TEXT")}
+
+		Expect(func() {
+			AsEnum(b, "<key>", "json", "text", "logfmt")
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "TEXT",
+			Explanation: `expected one of "json", "text" or "logfmt"`,
+		}))
+	})
+
+	It("describes a single allowed value", func() {
+		b := Map{"<key>": String("<invalid>")}
+
+		Expect(func() {
+			AsEnum(b, "<key>", "json")
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "<invalid>",
+			Explanation: `expected "json"`,
+		}))
+	})
+
+	It("panics if there are no allowed values", func() {
+		b := Map{"<key>": String("text")}
+
+		Expect(func() {
+			AsEnum(b, "<key>")
+		}).To(PanicWith("enum must have at least one permitted value"))
+	})
+})
+
+var _ = Describe("func AsEnumDefault()", func() {
+	It("returns the value", func() {
+		b := Map{"<key>": String("text")}
+
+		v := AsEnumDefault(b, "<key>", "json", "json", "text")
+		Expect(v).To(Equal("text"))
+	})
+
+	It("returns the default value if the key is not defined", func() {
+		v := AsEnumDefault(Map{}, "<key>", "json", "json", "text")
+		Expect(v).To(Equal("json"))
+	})
+
+	It("panics if the default value is not one of the allowed values", func() {
+		Expect(func() {
+			AsEnumDefault(Map{}, "<key>", "<invalid>", "json", "text")
+		}).To(PanicWith(InvalidDefaultValue{
+			Key:          "<key>",
+			DefaultValue: "<invalid>",
+			Explanation:  `expected one of "json" or "text"`,
+		}))
+	})
+})
+
+var _ = Describe("type Enum", func() {
+	enum := Enum{
+		Values: []string{"postgres", "mysql"},
+		Aliases: map[string]string{
+			"pg":         "postgres",
+			"postgresql": "postgres",
+		},
+		CaseInsensitive: true,
+	}
+
+	Describe("func As()", func() {
+		It("matches values without regard to case", func() {
+			b := Map{"<key>": String("MySQL")}
+
+			v := enum.As(b, "<key>")
+			Expect(v).To(Equal("mysql"))
+		})
+
+		It("maps aliases to their permitted value", func() {
+			b := Map{"<key>": String("PG")}
+
+			v := enum.As(b, "<key>")
+			Expect(v).To(Equal("postgres"))
+		})
+
+		It("panics if an alias refers to a value that is not permitted", func() {
+			e := Enum{
+				Values:  []string{"postgres"},
+				Aliases: map[string]string{"pg": "<invalid>"},
+			}
+
+			b := Map{"<key>": String("pg")}
+
+			Expect(func() {
+				e.As(b, "<key>")
+			}).To(PanicWith(`enum alias "pg" refers to "<invalid>", which is not a permitted value`))
+		})
+
+		It("panics if any alias refers to a value that is not permitted, even if it does not match", func() {
+			e := Enum{
+				Values: []string{"postgres", "mysql"},
+				Aliases: map[string]string{
+					"pg":    "postgres",
+					"maria": "<invalid>",
+				},
+			}
+
+			b := Map{"<key>": String("pg")}
+
+			Expect(func() {
+				e.As(b, "<key>")
+			}).To(PanicWith(`enum alias "maria" refers to "<invalid>", which is not a permitted value`))
+
+			Expect(func() {
+				e.AsDefault(Map{}, "<key>", "postgres")
+			}).To(PanicWith(`enum alias "maria" refers to "<invalid>", which is not a permitted value`))
+		})
+	})
+
+	Describe("func AsDefault()", func() {
+		It("maps the default value to its permitted value", func() {
+			v := enum.AsDefault(Map{}, "<key>", "PostgreSQL")
+			Expect(v).To(Equal("postgres"))
+		})
+	})
+
+	Describe("func TryAs()", func() {
+		It("returns an error if the value is not permitted", func() {
+			b := Map{"<key>": String("sqlite")}
+
+			_, err := enum.TryAs(b, "<key>")
+			Expect(err).To(Equal(InvalidValue{
+				Key:         "<key>",
+				Value:       "sqlite",
+				Explanation: `expected one of "postgres" or "mysql"`,
+			}))
+		})
+	})
+})
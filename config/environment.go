@@ -23,6 +23,9 @@ import (
 // ● the value "string:base64", then K contains a binary value with base-64 encoding
 //
 // ● the value "file", then K contains a path to a file containing the value
+//
+// Use FileSuffix() to also support the "_FILE" suffix convention used by many
+// official Docker images.
 func Environment() Bucket {
 	return environment{}
 }
@@ -123,34 +126,43 @@ const (
 
 // getenv returns the Value for the environment variable named k.
 func getenv(k string) Value {
-	raw := os.Getenv(k)
+	return resolve(k, os.Getenv, "environment variable "+k)
+}
+
+// resolve returns the Value for the variable named k.
+//
+// lookup is a function that returns the raw content of a variable, or an
+// empty string if it is undefined. It is used to obtain both k and its
+// data-source variable. origin describes where the variable is defined.
+func resolve(k string, lookup func(string) string, origin string) Value {
+	raw := lookup(k)
 
 	if raw == "" {
 		return Value{}
 	}
 
-	src := os.Getenv(k + suffix)
+	src := lookup(k + suffix)
 
 	switch src {
 	case "", sourceStringPlain:
-		return String(raw)
+		return described(String(raw), origin)
 
 	case sourceStringHex:
 		buf, err := hex.DecodeString(raw)
 		if err != nil {
 			return fail(err)
 		}
-		return Bytes(buf)
+		return described(Bytes(buf), origin+" ("+src+")")
 
 	case sourceStringBase64:
 		buf, err := base64.StdEncoding.DecodeString(raw)
 		if err != nil {
 			return fail(err)
 		}
-		return Bytes(buf)
+		return described(Bytes(buf), origin+" ("+src+")")
 
 	case sourceFile:
-		return File(raw)
+		return described(File(raw), origin)
 
 	default:
 		return fail(
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // KeyError is an error that indicates a problem with the value associated with
 // a specific key.
@@ -11,10 +14,42 @@ type KeyError interface {
 	ConfigKey() string
 }
 
+// KeyErrors is an error that aggregates several KeyError values.
+type KeyErrors []KeyError
+
+func (e KeyErrors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the individual errors, for use with errors.Is() and
+// errors.As().
+func (e KeyErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
 // NotDefined is an error used as a panic value when a requested key is not
 // defined.
 type NotDefined struct {
 	Key string
+
+	// Suggestion is a similarly named key that is defined, if any.
+	Suggestion string
+
+	// SuggestionSource describes where the suggested key is defined, if
+	// known.
+	SuggestionSource string
 }
 
 // ConfigKey returns the config key that the error relates to.
@@ -23,7 +58,42 @@ func (e NotDefined) ConfigKey() string {
 }
 
 func (e NotDefined) Error() string {
-	return fmt.Sprintf("%s is not defined", e.Key)
+	if e.Suggestion == "" {
+		return fmt.Sprintf("%s is not defined", e.Key)
+	}
+
+	if e.SuggestionSource == "" {
+		return fmt.Sprintf("%s is not defined, did you mean %s?", e.Key, e.Suggestion)
+	}
+
+	return fmt.Sprintf(
+		"%s is not defined, did you mean %s (from %s)?",
+		e.Key,
+		e.Suggestion,
+		e.SuggestionSource,
+	)
+}
+
+// Unused is an error that indicates a key is defined but is not used by the
+// application, as returned by FindUnused().
+type Unused struct {
+	Key string
+
+	// Suggestion is a similarly named key that is used, if any.
+	Suggestion string
+}
+
+// ConfigKey returns the config key that the error relates to.
+func (e Unused) ConfigKey() string {
+	return e.Key
+}
+
+func (e Unused) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("%s is not used, did you mean %s?", e.Key, e.Suggestion)
+	}
+
+	return fmt.Sprintf("%s is not used", e.Key)
 }
 
 // InvalidValue is an error used as a panic value when the value associated with
@@ -32,6 +102,9 @@ type InvalidValue struct {
 	Key         string
 	Value       string
 	Explanation string
+
+	// Source describes where the value was obtained, if known.
+	Source string
 }
 
 // ConfigKey returns the config key that the error relates to.
@@ -40,6 +113,16 @@ func (e InvalidValue) ConfigKey() string {
 }
 
 func (e InvalidValue) Error() string {
+	if e.Source != "" {
+		return fmt.Sprintf(
+			"%s has an invalid value (%#v) from %s: %s",
+			e.Key,
+			e.Value,
+			e.Source,
+			e.Explanation,
+		)
+	}
+
 	return fmt.Sprintf(
 		"%s has an invalid value (%#v): %s",
 		e.Key,
@@ -54,6 +137,9 @@ type InvalidDefaultValue struct {
 	Key          string
 	DefaultValue string
 	Explanation  string
+
+	// Source describes where the default value was specified, if known.
+	Source string
 }
 
 // ConfigKey returns the config key that the error relates to.
@@ -62,6 +148,16 @@ func (e InvalidDefaultValue) ConfigKey() string {
 }
 
 func (e InvalidDefaultValue) Error() string {
+	if e.Source != "" {
+		return fmt.Sprintf(
+			"%s has an invalid default value (%#v) from %s: %s",
+			e.Key,
+			e.DefaultValue,
+			e.Source,
+			e.Explanation,
+		)
+	}
+
 	return fmt.Sprintf(
 		"%s has an invalid default value (%#v): %s",
 		e.Key,
@@ -69,3 +165,84 @@ func (e InvalidDefaultValue) Error() string {
 		e.Explanation,
 	)
 }
+
+// Unreadable is an error used as a panic value when the value associated with
+// a key can not be read, such as when it refers to a file that does not
+// exist.
+type Unreadable struct {
+	Key   string
+	Cause error
+}
+
+// ConfigKey returns the config key that the error relates to.
+func (e Unreadable) ConfigKey() string {
+	return e.Key
+}
+
+func (e Unreadable) Error() string {
+	return fmt.Sprintf("cannot read %s: %s", e.Key, e.Cause)
+}
+
+// Unwrap returns the underlying cause of the error.
+func (e Unreadable) Unwrap() error {
+	return e.Cause
+}
+
+// UnsupportedType is an error used as a panic value when a key is requested as
+// a type for which no parser is registered using RegisterParser().
+type UnsupportedType struct {
+	Key  string
+	Type string
+}
+
+// ConfigKey returns the config key that the error relates to.
+func (e UnsupportedType) ConfigKey() string {
+	return e.Key
+}
+
+func (e UnsupportedType) Error() string {
+	return fmt.Sprintf("cannot represent %s as %s, no parser is registered for that type", e.Key, e.Type)
+}
+
+// unreadable returns the error to use when the value associated with k can not
+// be read.
+//
+// If err is already a KeyError, such as when a bucket has determined that the
+// value is invalid, it is returned unchanged.
+func unreadable(k string, err error) KeyError {
+	if e, ok := err.(KeyError); ok {
+		return e
+	}
+
+	return Unreadable{k, err}
+}
+
+// SyntaxError is an error that indicates a configuration document, such as a
+// dotenv file, is not well-formed.
+type SyntaxError struct {
+	// Path is the path of the document, if known.
+	Path string
+
+	// Line is the (1-based) line number at which the problem was found.
+	Line int
+
+	// Explanation describes the problem.
+	Explanation string
+}
+
+func (e SyntaxError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf(
+			"syntax error on line %d: %s",
+			e.Line,
+			e.Explanation,
+		)
+	}
+
+	return fmt.Sprintf(
+		"syntax error in %s on line %d: %s",
+		e.Path,
+		e.Line,
+		e.Explanation,
+	)
+}
@@ -1,7 +1,10 @@
 package config_test
 
 import (
+	"errors"
+
 	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
@@ -19,6 +22,38 @@ var _ = DescribeTable(
 			Key: "<key>",
 		},
 	),
+	Entry(
+		"type NotDefined (with suggestion)",
+		"<key> is not defined, did you mean <suggestion>?",
+		NotDefined{
+			Key:        "<key>",
+			Suggestion: "<suggestion>",
+		},
+	),
+	Entry(
+		"type NotDefined (with suggestion and source)",
+		"<key> is not defined, did you mean <suggestion> (from <source>)?",
+		NotDefined{
+			Key:              "<key>",
+			Suggestion:       "<suggestion>",
+			SuggestionSource: "<source>",
+		},
+	),
+	Entry(
+		"type Unused",
+		"<key> is not used",
+		Unused{
+			Key: "<key>",
+		},
+	),
+	Entry(
+		"type Unused (with suggestion)",
+		"<key> is not used, did you mean <suggestion>?",
+		Unused{
+			Key:        "<key>",
+			Suggestion: "<suggestion>",
+		},
+	),
 	Entry(
 		"type InvalidValue",
 		`<key> has an invalid value ("<value>"): <explanation>`,
@@ -28,6 +63,16 @@ var _ = DescribeTable(
 			Explanation: "<explanation>",
 		},
 	),
+	Entry(
+		"type InvalidValue (with source)",
+		`<key> has an invalid value ("<value>") from <source>: <explanation>`,
+		InvalidValue{
+			Key:         "<key>",
+			Value:       "<value>",
+			Explanation: "<explanation>",
+			Source:      "<source>",
+		},
+	),
 	Entry(
 		"type InvalidDefaultValue",
 		`<key> has an invalid default value ("<value>"): <explanation>`,
@@ -37,4 +82,60 @@ var _ = DescribeTable(
 			Explanation:  "<explanation>",
 		},
 	),
+	Entry(
+		"type InvalidDefaultValue (with source)",
+		`<key> has an invalid default value ("<value>") from <source>: <explanation>`,
+		InvalidDefaultValue{
+			Key:          "<key>",
+			DefaultValue: "<value>",
+			Explanation:  "<explanation>",
+			Source:       "<source>",
+		},
+	),
 )
+
+var _ = DescribeTable(
+	"type SyntaxError",
+	func(message string, err SyntaxError) {
+		Expect(err.Error()).To(Equal(message))
+	},
+	Entry(
+		"with a path",
+		"syntax error in <path> on line 10: <explanation>",
+		SyntaxError{
+			Path:        "<path>",
+			Line:        10,
+			Explanation: "<explanation>",
+		},
+	),
+	Entry(
+		"without a path",
+		"syntax error on line 10: <explanation>",
+		SyntaxError{
+			Line:        10,
+			Explanation: "<explanation>",
+		},
+	),
+)
+
+var _ = Describe("type KeyErrors", func() {
+	It("includes the message of every error", func() {
+		err := KeyErrors{
+			NotDefined{Key: "<key-1>"},
+			NotDefined{Key: "<key-2>"},
+		}
+
+		Expect(err.Error()).To(Equal("<key-1> is not defined\n<key-2> is not defined"))
+	})
+
+	It("can be unwrapped to the individual errors", func() {
+		err := KeyErrors{
+			NotDefined{Key: "<key-1>"},
+			InvalidValue{Key: "<key-2>"},
+		}
+
+		var target InvalidValue
+		Expect(errors.As(err, &target)).To(BeTrue())
+		Expect(target.Key).To(Equal("<key-2>"))
+	})
+})
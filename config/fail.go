@@ -31,3 +31,7 @@ func (s failSource) AsString() (string, error) {
 func (s failSource) AsBytes() ([]byte, error) {
 	return nil, s.err
 }
+
+func (s failSource) Source() string {
+	return ""
+}
@@ -33,3 +33,7 @@ func (s *fileSource) AsString() (string, error) {
 func (s *fileSource) AsBytes() ([]byte, error) {
 	return ioutil.ReadFile(s.path)
 }
+
+func (s *fileSource) Source() string {
+	return "file " + s.path
+}
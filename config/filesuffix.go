@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileSuffix returns a Bucket that supports the "_FILE" suffix convention used
+// by many official Docker images.
+//
+// For any given key K that is undefined in b, the key K_FILE may contain a path
+// to a file containing the value. It is invalid for both K and K_FILE to be
+// defined.
+//
+// Keys with the "_FILE" suffix are meta-data about configuration values, not
+// configuration values themselves, and as such are never returned by the
+// bucket.
+//
+// It is typically used to wrap the bucket returned by Environment().
+func FileSuffix(b Bucket) Bucket {
+	return fileSuffix{b}
+}
+
+// fileSuffix is an implementation of Bucket that supports the "_FILE" suffix
+// convention.
+type fileSuffix struct {
+	parent Bucket
+}
+
+// Get returns the value associated with the given key.
+//
+// If they key is not defined, it returns a zero-value.
+func (b fileSuffix) Get(k string) Value {
+	if isFileVariable(k) {
+		// never return the value of "_FILE" variables, they are meta-data
+		// about configuration values, not configuration values themselves.
+		return Value{}
+	}
+
+	f := b.parent.Get(k + fileSuffixSuffix)
+	if f.IsZero() {
+		return b.parent.Get(k)
+	}
+
+	p, err := f.AsString()
+	if err != nil {
+		return fail(unreadable(b.qualifiedKey(k+fileSuffixSuffix), err))
+	}
+
+	if x := b.parent.Get(k); !x.IsZero() {
+		return fail(InvalidValue{
+			Key:         b.qualifiedKey(k + fileSuffixSuffix),
+			Value:       p,
+			Explanation: fmt.Sprintf("expected %s to be undefined when %s is defined", b.qualifiedKey(k), b.qualifiedKey(k+fileSuffixSuffix)),
+			Source:      f.Source(),
+		})
+	}
+
+	return described(File(p), f.Source())
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// If the key is not defined, it returns a value with the content of v.
+func (b fileSuffix) GetDefault(k string, v string) Value {
+	x := b.Get(k)
+
+	if x.IsZero() {
+		return String(v)
+	}
+
+	return x
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+func (b fileSuffix) Each(fn EachFunc) bool {
+	return b.parent.Each(func(k string, v Value) bool {
+		if isFileVariable(k) {
+			base := strings.TrimSuffix(k, fileSuffixSuffix)
+
+			// Only visit the key that the "_FILE" variable refers to if it is
+			// not also defined in the parent, otherwise it is visited when the
+			// parent produces it.
+			if x := b.parent.Get(base); x.IsZero() {
+				return fn(base, b.Get(base))
+			}
+
+			return true
+		}
+
+		if v.IsZero() {
+			// The parent may produce zero-values, in which case the key is
+			// visited when its "_FILE" variable is produced, if any.
+			if f := b.parent.Get(k + fileSuffixSuffix); !f.IsZero() {
+				return true
+			}
+
+			return fn(k, v)
+		}
+
+		return fn(k, b.Get(k))
+	})
+}
+
+// qualifiedKey returns the key used to identify k in the parent bucket.
+func (b fileSuffix) qualifiedKey(k string) string {
+	return qualify(b.parent, k)
+}
+
+// fileSuffixSuffix is the suffix used to identify keys that contain the path
+// to a file containing the value of the key without this suffix.
+const fileSuffixSuffix = "_FILE"
+
+// isFileVariable returns true if k is the name of a "_FILE" variable.
+func isFileVariable(k string) bool {
+	return len(k) > len(fileSuffixSuffix) &&
+		strings.HasSuffix(k, fileSuffixSuffix)
+}
@@ -0,0 +1,130 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func FileSuffix()", func() {
+	var (
+		path   string
+		bucket Bucket
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join("testdata", "example.json")
+
+		bucket = FileSuffix(
+			Map{
+				"PLAIN":         String("<plain>"),
+				"SECRET_FILE":   String(path),
+				"CONFLICT":      String("<conflict>"),
+				"CONFLICT_FILE": String(path),
+			},
+		)
+	})
+
+	Describe("func Get()", func() {
+		It("returns the value of the key if there is no _FILE variable", func() {
+			v := bucket.Get("PLAIN")
+			Expect(v).To(Equal(String("<plain>")))
+		})
+
+		It("returns the content of the file named by the _FILE variable", func() {
+			v := bucket.Get("SECRET")
+			Expect(v.String()).To(Equal(`{"example_config": true}` + "\n"))
+
+			p, c, err := v.AsPath()
+			Expect(err).ShouldNot(HaveOccurred())
+			defer c.Close()
+			Expect(p).To(Equal(path))
+		})
+
+		It("returns the zero-value for _FILE variables", func() {
+			v := bucket.Get("SECRET_FILE")
+			Expect(v.IsZero()).To(BeTrue())
+		})
+
+		It("returns the zero-value if the key is undefined", func() {
+			v := bucket.Get("UNDEFINED")
+			Expect(v.IsZero()).To(BeTrue())
+		})
+
+		It("returns a value that produces an InvalidValue error if both the key and the _FILE variable are defined", func() {
+			v := bucket.Get("CONFLICT")
+			_, err := v.AsString()
+			Expect(err).To(Equal(InvalidValue{
+				Key:         "CONFLICT_FILE",
+				Value:       path,
+				Explanation: "expected CONFLICT to be undefined when CONFLICT_FILE is defined",
+			}))
+		})
+
+		It("causes the typed accessors to panic with an InvalidValue error if both the key and the _FILE variable are defined", func() {
+			Expect(func() {
+				AsString(bucket, "CONFLICT")
+			}).To(PanicWith(InvalidValue{
+				Key:         "CONFLICT_FILE",
+				Value:       path,
+				Explanation: "expected CONFLICT to be undefined when CONFLICT_FILE is defined",
+			}))
+		})
+
+		It("returns a value that produces an error if the file does not exist", func() {
+			bucket = FileSuffix(
+				Map{
+					"SECRET_FILE": String(filepath.Join("testdata", "does-not-exist")),
+				},
+			)
+
+			_, err := TryAsString(bucket, "SECRET")
+			Expect(err).To(BeAssignableToTypeOf(Unreadable{}))
+			Expect(os.IsNotExist(err.(Unreadable).Cause)).To(BeTrue())
+		})
+	})
+
+	Describe("func GetDefault()", func() {
+		It("returns the content of the file named by the _FILE variable", func() {
+			v := bucket.GetDefault("SECRET", "<default>")
+			Expect(v.String()).To(Equal(`{"example_config": true}` + "\n"))
+		})
+
+		It("returns the default value if neither variable is defined", func() {
+			v := bucket.GetDefault("UNDEFINED", "<default>")
+			Expect(v).To(Equal(String("<default>")))
+		})
+
+		It("returns the default value for _FILE variables", func() {
+			v := bucket.GetDefault("SECRET_FILE", "<default>")
+			Expect(v).To(Equal(String("<default>")))
+		})
+	})
+
+	Describe("func Each()", func() {
+		It("visits each key exactly once, excluding _FILE variables", func() {
+			var keys []string
+
+			Expect(bucket.Each(func(k string, v Value) bool {
+				keys = append(keys, k)
+				return true
+			})).To(BeTrue())
+
+			Expect(keys).To(ConsistOf("PLAIN", "SECRET", "CONFLICT"))
+		})
+
+		It("stops iterating if the function returns false", func() {
+			count := 0
+
+			Expect(bucket.Each(func(k string, v Value) bool {
+				count++
+				return false
+			})).To(BeFalse())
+
+			Expect(count).To(Equal(1))
+		})
+	})
+})
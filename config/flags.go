@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// Flags returns a bucket that produces configuration values from command-line
+// flags.
+//
+// A flag is registered with fs for each of the given keys. The name of each
+// flag is derived from the key by converting it to lowercase and replacing
+// underscores with hyphens, such that the HTTP_TIMEOUT key is set using the
+// --http-timeout flag.
+//
+// The description, default value and range of each key are included in the
+// flag's usage text. keys is typically obtained from Spec.Keys().
+//
+// The bucket contains only those flags that are explicitly set to a non-empty
+// value on the command line, such that it may be layered above Environment()
+// to override specific values for a single invocation:
+//
+//	fs := flag.NewFlagSet("app", flag.ExitOnError)
+//	flags := config.Flags(fs, spec.Keys())
+//	fs.Parse(os.Args[1:])
+//
+//	b := config.Layered(config.Environment(), flags)
+func Flags(fs *flag.FlagSet, keys []KeySpec) *FlagsBucket {
+	b := &FlagsBucket{
+		values: map[string]*flagValue{},
+	}
+
+	for _, k := range keys {
+		v := &flagValue{
+			flag:   flagName(k.Name),
+			usage:  flagUsage(k),
+			isBool: k.Type == "bool",
+		}
+
+		if !k.Sensitive {
+			v.def = k.Default
+		}
+
+		fs.Var(v, v.flag, v.usage)
+
+		b.keys = append(b.keys, k.Name)
+		b.values[k.Name] = v
+	}
+
+	return b
+}
+
+// flagName returns the name of the command-line flag that sets the key k.
+func flagName(k string) string {
+	return strings.ToLower(strings.ReplaceAll(k, "_", "-"))
+}
+
+// FlagsBucket is an implementation of Bucket that produces configuration values
+// from command-line flags.
+type FlagsBucket struct {
+	keys   []string
+	values map[string]*flagValue
+}
+
+// Get returns the value associated with the given key.
+//
+// If they key is not defined, it returns a zero-value.
+func (b *FlagsBucket) Get(k string) Value {
+	if v, ok := b.values[k]; ok && v.isSet && v.value != "" {
+		return described(String(v.value), "flag --"+v.flag)
+	}
+
+	return Value{}
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// If the key is not defined, it returns a value with the content of v.
+func (b *FlagsBucket) GetDefault(k string, v string) Value {
+	x := b.Get(k)
+
+	if x.IsZero() {
+		return String(v)
+	}
+
+	return x
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// Keys are visited in the order they were passed to Flags(). Only those keys
+// with flags that are explicitly set are visited.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+func (b *FlagsBucket) Each(fn EachFunc) bool {
+	for _, k := range b.keys {
+		if x := b.Get(k); !x.IsZero() {
+			if !fn(k, x) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Usage returns the usage text for the flags registered by Flags(), in the
+// same format as flag.FlagSet.PrintDefaults().
+func (b *FlagsBucket) Usage() string {
+	var buf bytes.Buffer
+
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.SetOutput(&buf)
+
+	for _, k := range b.keys {
+		// Register a copy of the value that has not been set, such that the
+		// usage text shows the default value, not the current value.
+		v := *b.values[k]
+		v.value, v.isSet = "", false
+
+		fs.Var(&v, v.flag, v.usage)
+	}
+
+	fs.PrintDefaults()
+
+	return buf.String()
+}
+
+// flagUsage returns the usage text for the flag that sets k.
+func flagUsage(k KeySpec) string {
+	var parts []string
+
+	if k.Description != "" {
+		parts = append(parts, k.Description)
+	}
+
+	if k.Min != "" || k.Max != "" {
+		parts = append(
+			parts,
+			fmt.Sprintf("Must be between %s and %s (inclusive).", k.Min, k.Max),
+		)
+	}
+
+	parts = append(parts, fmt.Sprintf("Overrides %s.", k.Name))
+
+	return strings.Join(parts, " ")
+}
+
+// flagValue is an implementation of flag.Value that records the value of a
+// flag that sets a configuration key.
+type flagValue struct {
+	flag   string
+	def    string
+	usage  string
+	isBool bool
+
+	value string
+	isSet bool
+}
+
+// String returns the current value of the flag, or its default value if it has
+// not been set.
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	if v.isSet {
+		return v.value
+	}
+
+	return v.def
+}
+
+// Set sets the value of the flag.
+func (v *flagValue) Set(s string) error {
+	v.value = s
+	v.isSet = true
+	return nil
+}
+
+// IsBoolFlag returns true if the flag may be specified without a value.
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
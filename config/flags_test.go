@@ -0,0 +1,115 @@
+package config_test
+
+import (
+	"flag"
+	"io"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Flags()", func() {
+	var (
+		fs     *flag.FlagSet
+		bucket *FlagsBucket
+	)
+
+	BeforeEach(func() {
+		var spec Spec
+		Declare(&spec, "HTTP_TIMEOUT", "The HTTP request timeout.", WithDefault(5*time.Second))
+		Declare(&spec, "WORKERS", "The number of workers.", WithRange(1, 16))
+		Declare[bool](&spec, "DEBUG", "Enable debug logging.").Optional()
+		Declare[string](&spec, "API_KEY", "The API key.", WithDefault("<secret>")).Sensitive()
+
+		fs = flag.NewFlagSet("<name>", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+
+		bucket = Flags(fs, spec.Keys())
+	})
+
+	It("registers a flag for each key", func() {
+		Expect(fs.Lookup("http-timeout")).NotTo(BeNil())
+		Expect(fs.Lookup("workers")).NotTo(BeNil())
+		Expect(fs.Lookup("debug")).NotTo(BeNil())
+		Expect(fs.Lookup("api-key")).NotTo(BeNil())
+	})
+
+	It("contains the values of flags that are set explicitly", func() {
+		err := fs.Parse([]string{"--http-timeout=10s", "-workers", "4"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(AsDuration(bucket, "HTTP_TIMEOUT")).To(Equal(10 * time.Second))
+		Expect(AsInt(bucket, "WORKERS")).To(Equal(4))
+	})
+
+	It("does not contain the values of flags that are not set", func() {
+		err := fs.Parse([]string{"--workers=4"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		v := bucket.Get("HTTP_TIMEOUT")
+		Expect(v.IsZero()).To(BeTrue())
+
+		var keys []string
+		bucket.Each(func(k string, _ Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		Expect(keys).To(ConsistOf("WORKERS"))
+	})
+
+	It("treats empty values as undefined", func() {
+		err := fs.Parse([]string{"--workers="})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		v := bucket.Get("WORKERS")
+		Expect(v.IsZero()).To(BeTrue())
+	})
+
+	It("allows boolean flags to be specified without a value", func() {
+		err := fs.Parse([]string{"--debug"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(AsBool(bucket, "DEBUG")).To(BeTrue())
+	})
+
+	It("describes the flag as the source of the value", func() {
+		err := fs.Parse([]string{"--http-timeout=10s"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(bucket.Get("HTTP_TIMEOUT").Source()).To(Equal("flag --http-timeout"))
+	})
+
+	It("overrides the environment when layered above it", func() {
+		env := Map{
+			"HTTP_TIMEOUT": String("1s"),
+			"WORKERS":      String("2"),
+		}
+
+		err := fs.Parse([]string{"--workers=4"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		b := Layered(env, bucket)
+		Expect(AsDuration(b, "HTTP_TIMEOUT")).To(Equal(1 * time.Second))
+		Expect(AsInt(b, "WORKERS")).To(Equal(4))
+	})
+
+	Describe("func Usage()", func() {
+		It("describes each flag, including its default value", func() {
+			err := fs.Parse([]string{"--http-timeout=10s"})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(bucket.Usage()).To(Equal(
+				"  -api-key value\n" +
+					"    \tThe API key. Overrides API_KEY.\n" +
+					"  -debug\n" +
+					"    \tEnable debug logging. Overrides DEBUG.\n" +
+					"  -http-timeout value\n" +
+					"    \tThe HTTP request timeout. Overrides HTTP_TIMEOUT. (default 5s)\n" +
+					"  -workers value\n" +
+					"    \tThe number of workers. Must be between 1 and 16 (inclusive). Overrides WORKERS.\n",
+			))
+		})
+	})
+})
@@ -9,13 +9,31 @@ import (
 // AsFloat32 returns the float32 representation of the value associated with k
 // or panics if unable to do so.
 func AsFloat32(b Bucket, k string) float32 {
-	return float32(asFloat(b, k, 32, -math.MaxFloat32, math.MaxFloat32))
+	return float32(asFloat(b, k, accessor{"AsFloat32", false}, 32, -math.MaxFloat32, math.MaxFloat32))
+}
+
+// TryAsFloat32 returns the float32 representation of the value associated with
+// k, or an error if unable to do so.
+func TryAsFloat32(b Bucket, k string) (float32, error) {
+	return try(func() float32 {
+		return float32(asFloat(b, k, accessor{"TryAsFloat32", false}, 32, -math.MaxFloat32, math.MaxFloat32))
+	})
 }
 
 // AsFloat32Default returns the float32 representation of the value associated
 // with k, or the default value v if k is undefined.
 func AsFloat32Default(b Bucket, k string, v float32) float32 {
-	return float32(asFloatDefault(b, k, 32, float64(v), -math.MaxFloat32, math.MaxFloat32))
+	return float32(asFloatDefault(b, k, accessor{"AsFloat32Default", false}, 32, float64(v), -math.MaxFloat32, math.MaxFloat32))
+}
+
+// TryAsFloat32Default returns the float32 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsFloat32Default(b Bucket, k string, v float32) (float32, error) {
+	return try(func() float32 {
+		return float32(asFloatDefault(b, k, accessor{"TryAsFloat32Default", false}, 32, float64(v), -math.MaxFloat32, math.MaxFloat32))
+	})
 }
 
 // AsFloat32Between returns the float32 representation of the value associated
@@ -23,7 +41,17 @@ func AsFloat32Default(b Bucket, k string, v float32) float32 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsFloat32Between(b Bucket, k string, min, max float32) float32 {
-	return float32(asFloat(b, k, 32, float64(min), float64(max)))
+	return float32(asFloat(b, k, accessor{"AsFloat32Between", true}, 32, float64(min), float64(max)))
+}
+
+// TryAsFloat32Between returns the float32 representation of the value
+// associated with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsFloat32Between(b Bucket, k string, min, max float32) (float32, error) {
+	return try(func() float32 {
+		return float32(asFloat(b, k, accessor{"TryAsFloat32Between", true}, 32, float64(min), float64(max)))
+	})
 }
 
 // AsFloat32DefaultBetween returns the float32 representation of the value
@@ -31,19 +59,47 @@ func AsFloat32Between(b Bucket, k string, min, max float32) float32 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsFloat32DefaultBetween(b Bucket, k string, v, min, max float32) float32 {
-	return float32(asFloatDefault(b, k, 32, float64(v), float64(min), float64(max)))
+	return float32(asFloatDefault(b, k, accessor{"AsFloat32DefaultBetween", true}, 32, float64(v), float64(min), float64(max)))
+}
+
+// TryAsFloat32DefaultBetween returns the float32 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsFloat32DefaultBetween(b Bucket, k string, v, min, max float32) (float32, error) {
+	return try(func() float32 {
+		return float32(asFloatDefault(b, k, accessor{"TryAsFloat32DefaultBetween", true}, 32, float64(v), float64(min), float64(max)))
+	})
 }
 
 // AsFloat64 returns the float64 representation of the value associated with k
 // or panics if unable to do so.
 func AsFloat64(b Bucket, k string) float64 {
-	return asFloat(b, k, 64, -math.MaxFloat64, math.MaxFloat64)
+	return asFloat(b, k, accessor{"AsFloat64", false}, 64, -math.MaxFloat64, math.MaxFloat64)
+}
+
+// TryAsFloat64 returns the float64 representation of the value associated with
+// k, or an error if unable to do so.
+func TryAsFloat64(b Bucket, k string) (float64, error) {
+	return try(func() float64 {
+		return asFloat(b, k, accessor{"TryAsFloat64", false}, 64, -math.MaxFloat64, math.MaxFloat64)
+	})
 }
 
 // AsFloat64Default returns the float64 representation of the value associated
 // with k, or the default value v if k is undefined.
 func AsFloat64Default(b Bucket, k string, v float64) float64 {
-	return asFloatDefault(b, k, 64, v, -math.MaxFloat64, math.MaxFloat64)
+	return asFloatDefault(b, k, accessor{"AsFloat64Default", false}, 64, v, -math.MaxFloat64, math.MaxFloat64)
+}
+
+// TryAsFloat64Default returns the float64 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsFloat64Default(b Bucket, k string, v float64) (float64, error) {
+	return try(func() float64 {
+		return asFloatDefault(b, k, accessor{"TryAsFloat64Default", false}, 64, v, -math.MaxFloat64, math.MaxFloat64)
+	})
 }
 
 // AsFloat64Between returns the float64 representation of the value associated
@@ -51,7 +107,17 @@ func AsFloat64Default(b Bucket, k string, v float64) float64 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsFloat64Between(b Bucket, k string, min, max float64) float64 {
-	return asFloat(b, k, 64, min, max)
+	return asFloat(b, k, accessor{"AsFloat64Between", true}, 64, min, max)
+}
+
+// TryAsFloat64Between returns the float64 representation of the value
+// associated with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsFloat64Between(b Bucket, k string, min, max float64) (float64, error) {
+	return try(func() float64 {
+		return asFloat(b, k, accessor{"TryAsFloat64Between", true}, 64, min, max)
+	})
 }
 
 // AsFloat64DefaultBetween returns the float64 representation of the value
@@ -59,40 +125,55 @@ func AsFloat64Between(b Bucket, k string, min, max float64) float64 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsFloat64DefaultBetween(b Bucket, k string, v, min, max float64) float64 {
-	return asFloatDefault(b, k, 64, v, min, max)
+	return asFloatDefault(b, k, accessor{"AsFloat64DefaultBetween", true}, 64, v, min, max)
+}
+
+// TryAsFloat64DefaultBetween returns the float64 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsFloat64DefaultBetween(b Bucket, k string, v, min, max float64) (float64, error) {
+	return try(func() float64 {
+		return asFloatDefault(b, k, accessor{"TryAsFloat64DefaultBetween", true}, 64, v, min, max)
+	})
 }
 
 func tryAsFloat(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	min, max float64,
 ) (float64, bool) {
-	x := b.Get(k)
+	annotateRange(b, k, a, min, max)
+
+	x := access(b, k, a)
 
 	if x.IsZero() {
 		return 0, false
 	}
 
-	s := mustAsString(k, x)
+	s := mustAsString(qualify(b, k), x)
 	v, err := strconv.ParseFloat(s, bitSize)
 	if err != nil {
 		panic(InvalidValue{
-			k,
-			s,
-			fmt.Sprintf(`expected a %d-bit floating-point number`, bitSize),
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: fmt.Sprintf(`expected a %d-bit floating-point number`, bitSize),
+			Source:      x.Source(),
 		})
 	}
 
 	if min > v || v > max {
 		panic(InvalidValue{
-			k,
-			s,
-			fmt.Sprintf(
+			Key:   qualify(b, k),
+			Value: s,
+			Explanation: fmt.Sprintf(
 				`expected a number between %f and %f (inclusive)`,
 				min,
 				max,
 			),
+			Source: x.Source(),
 		})
 	}
 
@@ -102,27 +183,31 @@ func tryAsFloat(
 func asFloat(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	min, max float64,
 ) float64 {
-	if v, ok := tryAsFloat(b, k, bitSize, min, max); ok {
+	if v, ok := tryAsFloat(b, k, a, bitSize, min, max); ok {
 		return v
 	}
 
-	panic(NotDefined{k})
+	panic(notDefined(b, k))
 }
 
 func asFloatDefault(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	d, min, max float64,
 ) float64 {
+	annotateDefault(b, k, a, d)
+
 	if min > d || d > max {
 		panic(InvalidDefaultValue{
-			k,
-			fmt.Sprintf(`%f`, d),
-			fmt.Sprintf(
+			Key:          qualify(b, k),
+			DefaultValue: fmt.Sprintf(`%f`, d),
+			Explanation: fmt.Sprintf(
 				`expected a number between %f and %f (inclusive)`,
 				min,
 				max,
@@ -130,7 +215,7 @@ func asFloatDefault(
 		})
 	}
 
-	if v, ok := tryAsFloat(b, k, bitSize, min, max); ok {
+	if v, ok := tryAsFloat(b, k, a, bitSize, min, max); ok {
 		return v
 	}
 
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// FS returns a Bucket that produces configuration values from the file or
+// directory named root within fsys.
+//
+// It is typically used to ship default configuration within the binary using
+// an embed.FS, such that it may be overridden by layering Environment() above
+// it.
+//
+// If root is a directory, each regular file within it is a key, the value of
+// which is the content of the file, as per Directory(). Use "." to refer to
+// the root of fsys.
+//
+// If root is a file, it is parsed according to its extension:
+//
+// ● ".env" files are parsed as per DotEnv()
+//
+// ● ".json" files are parsed as per JSONFile()
+//
+// ● ".yaml" and ".yml" files are parsed as per YAMLFile()
+//
+// ● ".toml" files are parsed as per TOMLFile()
+//
+// ● ".ini" files are parsed as per INIFile()
+//
+// Files within fsys do not necessarily exist on disk, and so Value.AsPath()
+// returns the path to a temporary file containing the value.
+func FS(fsys fs.FS, root string) (Bucket, error) {
+	info, err := fs.Stat(fsys, root)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return fsDirectory{fsys, root}, nil
+	}
+
+	f, err := fsys.Open(root)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch ext := path.Ext(root); ext {
+	case ".env":
+		return parseDotEnv(root, f)
+	case ".json":
+		return parseJSON(root, f, nil)
+	case ".yaml", ".yml":
+		return parseYAML(root, f, nil)
+	case ".toml":
+		return parseTOML(root, f, nil)
+	case ".ini":
+		return parseINI(root, f, nil)
+	default:
+		return nil, fmt.Errorf("%s is not a recognised configuration file format", root)
+	}
+}
+
+// fsDirectory is an implementation of Bucket that sources values from files in
+// a directory within an fs.FS.
+type fsDirectory struct {
+	fsys fs.FS
+	path string
+}
+
+// Get returns the value associated with the given key.
+//
+// If they key is not defined, it returns a zero-value.
+func (d fsDirectory) Get(k string) Value {
+	if !isDirectoryKey(k) {
+		return Value{}
+	}
+
+	p := path.Join(d.path, k)
+
+	info, err := fs.Stat(d.fsys, p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Value{}
+		}
+
+		return fail(err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return Value{}
+	}
+
+	return Value{&fsFileSource{fsys: d.fsys, path: p}}
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// If the key is not defined, it returns a value with the content of v.
+func (d fsDirectory) GetDefault(k string, v string) Value {
+	x := d.Get(k)
+
+	if x.IsZero() {
+		return String(v)
+	}
+
+	return x
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+//
+// If the directory can not be read the bucket is empty, as per Directory().
+func (d fsDirectory) Each(fn EachFunc) bool {
+	ok, _ := d.tryEach(fn)
+	return ok
+}
+
+// tryEach calls fn for each key/value pair in the bucket, as per Each().
+//
+// It returns an error if the directory can not be read.
+func (d fsDirectory) tryEach(fn EachFunc) (bool, error) {
+	entries, err := fs.ReadDir(d.fsys, d.path)
+	if err != nil {
+		return true, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		if x := d.Get(k); !x.IsZero() {
+			if !fn(k, x) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// fsFileSource is an implementation of the source interface for configuration
+// values that are specified as a file within an fs.FS.
+type fsFileSource struct {
+	fsys fs.FS
+	path string
+	temp tempfile
+}
+
+func (s *fsFileSource) AsReader() (io.ReadCloser, error) {
+	return s.fsys.Open(s.path)
+}
+
+func (s *fsFileSource) AsPath() (string, io.Closer, error) {
+	fn := func(w io.Writer) error {
+		f, err := s.fsys.Open(s.path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(w, f)
+		return err
+	}
+
+	path, err := s.temp.addRef(fn)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return path, &closer{fn: s.temp.decRef}, nil
+}
+
+func (s *fsFileSource) AsString() (string, error) {
+	data, err := s.AsBytes()
+	return string(data), err
+}
+
+func (s *fsFileSource) AsBytes() ([]byte, error) {
+	return fs.ReadFile(s.fsys, s.path)
+}
+
+func (s *fsFileSource) Source() string {
+	return "file " + s.path + " (fs.FS)"
+}
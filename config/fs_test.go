@@ -0,0 +1,193 @@
+package config_test
+
+import (
+	"io/fs"
+	"os"
+	"testing/fstest"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func FS()", func() {
+	fsys := fstest.MapFS{
+		"config/KEY_1":        {Data: []byte("<value-1>")},
+		"config/KEY_2":        {Data: []byte("<value-2>")},
+		"config/.hidden":      {Data: []byte("<hidden>")},
+		"config/subdir/KEY_3": {Data: []byte("<value-3>")},
+		"app.env":             {Data: []byte("# comment\nFOO=<foo>\n")},
+		"app.json":            {Data: []byte(`{"http": {"timeout": "5s"}}`)},
+		"app.yaml":            {Data: []byte("http:\n  timeout: 5s\n")},
+		"app.toml":            {Data: []byte("[http]\ntimeout = \"5s\"\n")},
+		"app.ini":             {Data: []byte("[http]\ntimeout = 5s\n")},
+		"app.txt":             {Data: []byte("<text>")},
+	}
+
+	It("returns an error if root does not exist", func() {
+		_, err := FS(fsys, "does-not-exist")
+		Expect(err).To(MatchError(fs.ErrNotExist))
+	})
+
+	It("returns an error if root is a file with an unrecognised extension", func() {
+		_, err := FS(fsys, "app.txt")
+		Expect(err).To(MatchError("app.txt is not a recognised configuration file format"))
+	})
+
+	When("root is a directory", func() {
+		var bucket Bucket
+
+		// unreadable returns a bucket for a directory that is replaced by a
+		// file after the bucket is created, such that it can not be read.
+		unreadable := func() Bucket {
+			fsys := fstest.MapFS{
+				"config/KEY_1": {Data: []byte("<value-1>")},
+			}
+
+			b, err := FS(fsys, "config")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			delete(fsys, "config/KEY_1")
+			fsys["config"] = &fstest.MapFile{Data: []byte("<not a directory>")}
+
+			return b
+		}
+
+		BeforeEach(func() {
+			var err error
+			bucket, err = FS(fsys, "config")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		Describe("func Get()", func() {
+			It("returns the content of the file", func() {
+				v := bucket.Get("KEY_1")
+				Expect(v.String()).To(Equal("<value-1>"))
+				Expect(v.Source()).To(Equal("file config/KEY_1 (fs.FS)"))
+			})
+
+			It("returns the zero-value if the file does not exist", func() {
+				v := bucket.Get("UNDEFINED")
+				Expect(v.IsZero()).To(BeTrue())
+			})
+
+			It("returns the zero-value for hidden entries, directories and paths", func() {
+				for _, k := range []string{".hidden", "subdir", "subdir/KEY_3", ""} {
+					v := bucket.Get(k)
+					Expect(v.IsZero()).To(BeTrue(), k)
+				}
+			})
+
+			It("returns a value with a path to a temporary file", func() {
+				v := bucket.Get("KEY_1")
+
+				p, c, err := v.AsPath()
+				Expect(err).ShouldNot(HaveOccurred())
+
+				data, err := os.ReadFile(p)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(string(data)).To(Equal("<value-1>"))
+
+				err = c.Close()
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = os.Stat(p)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+
+		Describe("func GetDefault()", func() {
+			It("returns the content of the file", func() {
+				v := bucket.GetDefault("KEY_2", "<default>")
+				Expect(v.String()).To(Equal("<value-2>"))
+			})
+
+			It("returns the default value if the file does not exist", func() {
+				v := bucket.GetDefault("UNDEFINED", "<default>")
+				Expect(v.String()).To(Equal("<default>"))
+			})
+		})
+
+		Describe("func Each()", func() {
+			It("visits each file in order", func() {
+				var keys []string
+				bucket.Each(func(k string, v Value) bool {
+					keys = append(keys, k+"="+v.String())
+					return true
+				})
+
+				Expect(keys).To(Equal([]string{
+					"KEY_1=<value-1>",
+					"KEY_2=<value-2>",
+				}))
+			})
+
+			It("does not invoke the function if the directory can not be read", func() {
+				bucket := unreadable()
+
+				ok := bucket.Each(func(k string, v Value) bool {
+					Fail("unexpected call")
+					return true
+				})
+
+				Expect(ok).To(BeTrue())
+			})
+		})
+
+		When("the directory can not be read", func() {
+			It("contributes no keys to a layered bucket", func() {
+				var keys []string
+				Layered(Map{"KEY_3": String("<value-3>")}, unreadable()).Each(
+					func(k string, v Value) bool {
+						keys = append(keys, k)
+						return true
+					},
+				)
+
+				Expect(keys).To(Equal([]string{"KEY_3"}))
+			})
+
+			It("does not report any unused keys", func() {
+				bucket := unreadable()
+
+				Expect(FindUnused(bucket, "", []string{"KEY_1"})).To(BeEmpty())
+				Expect(FindUnused(Layered(bucket), "", []string{"KEY_1"})).To(BeEmpty())
+			})
+		})
+
+		It("supports the root of the filesystem", func() {
+			b, err := FS(fsys, ".")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			v := b.Get("app.txt")
+			Expect(v.String()).To(Equal("<text>"))
+		})
+	})
+
+	When("root is a file", func() {
+		It("parses dotenv files", func() {
+			b, err := FS(fsys, "app.env")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			v := b.Get("FOO")
+			Expect(v.String()).To(Equal("<foo>"))
+			Expect(v.Source()).To(Equal("line 2 of app.env"))
+		})
+
+		DescribeTable(
+			"it parses structured documents",
+			func(root string) {
+				b, err := FS(fsys, root)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				v := b.Get("HTTP_TIMEOUT")
+				Expect(v.String()).To(Equal("5s"))
+			},
+			Entry("JSON", "app.json"),
+			Entry("YAML", "app.yaml"),
+			Entry("TOML", "app.toml"),
+			Entry("INI", "app.ini"),
+		)
+	})
+})
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Option is an option that changes the behavior of Get() and TryGet().
+type Option[T any] func(*options[T])
+
+// options is the set of options applied by Get() and TryGet().
+type options[T any] struct {
+	hasDefault bool
+	def        T
+	check      func(T) string
+	min, max   string
+}
+
+// WithDefault returns an option that sets the value to use when the key is
+// undefined.
+func WithDefault[T any](v T) Option[T] {
+	return func(o *options[T]) {
+		o.hasDefault = true
+		o.def = v
+	}
+}
+
+// WithRange returns an option that requires the value to be between min and
+// max (inclusive).
+func WithRange[T cmp.Ordered](min, max T) Option[T] {
+	return func(o *options[T]) {
+		o.min = fmt.Sprint(min)
+		o.max = fmt.Sprint(max)
+		o.check = func(v T) string {
+			if min <= v && v <= max {
+				return ""
+			}
+
+			return fmt.Sprintf(
+				`expected a value between %v and %v (inclusive)`,
+				min,
+				max,
+			)
+		}
+	}
+}
+
+// Get returns the representation of the value associated with k as type T, or
+// panics if unable to do so.
+//
+// The value is parsed using the function registered for T by RegisterParser().
+// There are built-in parsers for string, []byte, bool, the signed and unsigned
+// integer types, float32, float64, time.Duration and *url.URL. It panics with
+// an UnsupportedType error if there is no parser registered for T.
+//
+// It panics if k is undefined and no default value is specified using the
+// WithDefault() option.
+func Get[T any](b Bucket, k string, opts ...Option[T]) T {
+	return get(b, k, "Get", opts)
+}
+
+// TryGet returns the representation of the value associated with k as type T,
+// or an error if unable to do so.
+//
+// See Get() for more information.
+func TryGet[T any](b Bucket, k string, opts ...Option[T]) (T, error) {
+	return try(func() T {
+		return get(b, k, "TryGet", opts)
+	})
+}
+
+func get[T any](
+	b Bucket,
+	k string,
+	name string,
+	opts []Option[T],
+) T {
+	var o options[T]
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	a := accessor{name, o.min != ""}
+
+	if o.hasDefault && o.check != nil {
+		if expl := o.check(o.def); expl != "" {
+			panic(InvalidDefaultValue{
+				Key:          qualify(b, k),
+				DefaultValue: fmt.Sprint(o.def),
+				Explanation:  expl,
+			})
+		}
+	}
+
+	if o.hasDefault {
+		annotateDefault(b, k, a, o.def)
+	}
+
+	annotateRange(b, k, a, o.min, o.max)
+
+	parse := parserFor[T](b, k)
+
+	x := access(b, k, a)
+
+	if x.IsZero() {
+		if o.hasDefault {
+			return o.def
+		}
+
+		panic(notDefined(b, k))
+	}
+
+	s := mustAsString(qualify(b, k), x)
+
+	v, err := parse(s)
+	if err != nil {
+		panic(InvalidValue{
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: err.Error(),
+			Source:      x.Source(),
+		})
+	}
+
+	if o.check != nil {
+		if expl := o.check(v); expl != "" {
+			panic(InvalidValue{
+				Key:         qualify(b, k),
+				Value:       s,
+				Explanation: expl,
+				Source:      x.Source(),
+			})
+		}
+	}
+
+	return v
+}
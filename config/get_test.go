@@ -0,0 +1,150 @@
+package config_test
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Get()", func() {
+	DescribeTable(
+		"it parses values using the built-in parsers",
+		func(fn func(Bucket) interface{}, value string, expect interface{}) {
+			b := Map{"<key>": String(value)}
+			Expect(fn(b)).To(Equal(expect))
+		},
+		Entry("string", func(b Bucket) interface{} { return Get[string](b, "<key>") }, "<value>", "<value>"),
+		Entry("[]byte", func(b Bucket) interface{} { return Get[[]byte](b, "<key>") }, "<value>", []byte("<value>")),
+		Entry("bool", func(b Bucket) interface{} { return Get[bool](b, "<key>") }, "on", true),
+		Entry("int", func(b Bucket) interface{} { return Get[int](b, "<key>") }, "-7", -7),
+		Entry("int8", func(b Bucket) interface{} { return Get[int8](b, "<key>") }, "-7", int8(-7)),
+		Entry("uint16", func(b Bucket) interface{} { return Get[uint16](b, "<key>") }, "7", uint16(7)),
+		Entry("uint64", func(b Bucket) interface{} { return Get[uint64](b, "<key>") }, "7", uint64(7)),
+		Entry("float32", func(b Bucket) interface{} { return Get[float32](b, "<key>") }, "0.5", float32(0.5)),
+		Entry("time.Duration", func(b Bucket) interface{} { return Get[time.Duration](b, "<key>") }, "5s", 5*time.Second),
+		Entry("*url.URL", func(b Bucket) interface{} { return Get[*url.URL](b, "<key>").String() }, "http://example.org", "http://example.org"),
+	)
+
+	It("returns the default value if the key is undefined", func() {
+		v := Get(Map{}, "<key>", WithDefault(5*time.Second))
+		Expect(v).To(Equal(5 * time.Second))
+	})
+
+	It("panics if the key is undefined and there is no default", func() {
+		Expect(func() {
+			Get[int](Map{}, "<key>")
+		}).To(PanicWith(NotDefined{Key: "<key>"}))
+	})
+
+	It("panics with the parser's explanation if the value is invalid", func() {
+		b := Map{"<key>": String("<invalid>")}
+
+		Expect(func() {
+			Get[int8](b, "<key>")
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "<invalid>",
+			Explanation: `expected an integer between -128 and 127 (inclusive)`,
+		}))
+	})
+
+	It("panics if the value is out of range", func() {
+		b := Map{"<key>": String("2m")}
+
+		Expect(func() {
+			Get(b, "<key>", WithRange(time.Second, time.Minute))
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "2m",
+			Explanation: `expected a value between 1s and 1m0s (inclusive)`,
+		}))
+	})
+
+	It("panics if the default value is out of range", func() {
+		Expect(func() {
+			Get(Map{}, "<key>", WithDefault(20), WithRange(1, 10))
+		}).To(PanicWith(InvalidDefaultValue{
+			Key:          "<key>",
+			DefaultValue: "20",
+			Explanation:  `expected a value between 1 and 10 (inclusive)`,
+		}))
+	})
+
+	It("panics if there is no parser registered for the type", func() {
+		type unregistered struct{}
+
+		Expect(func() {
+			Get[unregistered](Map{}, "<key>")
+		}).To(PanicWith(UnsupportedType{
+			Key:  "<key>",
+			Type: "config_test.unregistered",
+		}))
+	})
+})
+
+var _ = Describe("func TryGet()", func() {
+	It("returns the value", func() {
+		b := Map{"<key>": String("7")}
+
+		v, err := TryGet(b, "<key>", WithRange(1, 10))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).To(Equal(7))
+	})
+
+	It("returns an error if the value is invalid", func() {
+		b := Map{"<key>": String("<invalid>")}
+
+		_, err := TryGet[bool](b, "<key>")
+		Expect(err).To(Equal(InvalidValue{
+			Key:         "<key>",
+			Value:       "<invalid>",
+			Explanation: `expected a boolean ("true", "false", "yes", "no", "on" or "off")`,
+		}))
+	})
+
+	It("returns an error if there is no parser registered for the type", func() {
+		b := Map{"<key>": String("<value>")}
+
+		_, err := TryGet[struct{}](b, "<key>")
+		Expect(err).To(Equal(UnsupportedType{
+			Key:  "<key>",
+			Type: "struct {}",
+		}))
+		Expect(err).To(MatchError("cannot represent <key> as struct {}, no parser is registered for that type"))
+	})
+})
+
+var _ = Describe("func RegisterParser()", func() {
+	It("registers a parser for a custom type", func() {
+		RegisterParser(func(s string) (netip.Addr, error) {
+			v, err := netip.ParseAddr(s)
+			if err != nil {
+				return netip.Addr{}, errors.New("expected an IP address")
+			}
+
+			return v, nil
+		})
+
+		b := Map{
+			"<key>":     String("192.168.0.1"),
+			"<invalid>": String("<invalid>"),
+		}
+
+		v := Get[netip.Addr](b, "<key>")
+		Expect(v).To(Equal(netip.MustParseAddr("192.168.0.1")))
+
+		Expect(func() {
+			Get[netip.Addr](b, "<invalid>")
+		}).To(PanicWith(InvalidValue{
+			Key:         "<invalid>",
+			Value:       "<invalid>",
+			Explanation: `expected an IP address`,
+		}))
+	})
+})
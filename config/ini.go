@@ -0,0 +1,158 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// INIFile returns a Bucket that produces configuration values from the INI
+// file at path p.
+//
+// Each line of the file is either blank, a comment beginning with ";" or "#",
+// a section header of the form [SECTION], or a key/value pair of the form
+// KEY=VALUE or KEY: VALUE.
+//
+// Sections are mapped to key prefixes, such that the "host" key within the
+// "[database]" section is mapped to the DATABASE_HOST bucket key. Keys that
+// appear before the first section header have no prefix. The way in which
+// keys are mapped can be changed using the DocumentKeySeparator() and
+// DocumentKeyCase() options.
+//
+// Leading and trailing whitespace is removed from each value. Any text
+// following a ";" or "#" that is preceded by whitespace is treated as a
+// comment. A value may be enclosed in single or double quotes in order to
+// retain its whitespace or include comment characters; escape sequences are
+// not supported. Empty values are treated as undefined.
+//
+// It returns a SyntaxError if the file is not well-formed, or if two keys in
+// the file map to the same bucket key.
+func INIFile(p string, opts ...DocumentOption) (Bucket, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseINI(p, f, opts)
+}
+
+// INIReader returns a Bucket that produces configuration values from the INI
+// content in r.
+//
+// See INIFile() for a description of the supported syntax.
+func INIReader(r io.Reader, opts ...DocumentOption) (Bucket, error) {
+	return parseINI("", r, opts)
+}
+
+// parseINI parses the INI content in r and returns a bucket containing its
+// values.
+//
+// p is the path to the file, used only for error reporting.
+func parseINI(p string, r io.Reader, opts []DocumentOption) (Bucket, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data := strings.ReplaceAll(string(buf), "\r\n", "\n")
+
+	syntaxError := func(line int, explanation string) error {
+		return SyntaxError{
+			Path:        p,
+			Line:        line,
+			Explanation: explanation,
+		}
+	}
+
+	root := docNode{Line: 1, Kind: docObject}
+	section := -1 // index of the current section within root.Members
+
+	for i, text := range strings.Split(data, "\n") {
+		line := i + 1
+		text = strings.TrimSpace(text)
+
+		if text == "" || text[0] == ';' || text[0] == '#' {
+			continue
+		}
+
+		if text[0] == '[' {
+			end := strings.IndexByte(text, ']')
+			if end == -1 {
+				return nil, syntaxError(line, "expected ']' after section name")
+			}
+
+			if rest := strings.TrimSpace(text[end+1:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+				return nil, syntaxError(line, "unexpected content after section header")
+			}
+
+			name := strings.TrimSpace(text[1:end])
+			if name == "" {
+				return nil, syntaxError(line, "expected a section name")
+			}
+
+			section = -1
+			for j, m := range root.Members {
+				if m.Name == name && m.Value.Kind == docObject {
+					section = j
+				}
+			}
+
+			if section == -1 {
+				root.Members = append(root.Members, docMember{name, docNode{Line: line, Kind: docObject}})
+				section = len(root.Members) - 1
+			}
+
+			continue
+		}
+
+		sep := strings.IndexAny(text, "=:")
+		if sep == -1 {
+			return nil, syntaxError(line, "expected '=' after "+text)
+		}
+
+		k := strings.TrimSpace(text[:sep])
+		if k == "" {
+			return nil, syntaxError(line, "expected a key")
+		}
+
+		v, err := parseINIValue(strings.TrimSpace(text[sep+1:]))
+		if err != nil {
+			return nil, syntaxError(line, err.Error())
+		}
+
+		t := &root
+		if section != -1 {
+			t = &root.Members[section].Value
+		}
+
+		t.Members = append(t.Members, docMember{k, docNode{Line: line, Kind: docScalar, Scalar: v}})
+	}
+
+	return flattenDocument(p, root, opts)
+}
+
+// parseINIValue parses the (trimmed) value of an INI key/value pair.
+func parseINIValue(v string) (string, error) {
+	if v != "" && (v[0] == '"' || v[0] == '\'') {
+		end := strings.IndexByte(v[1:], v[0])
+		if end == -1 {
+			return "", errors.New("unterminated quoted value")
+		}
+
+		if rest := strings.TrimSpace(v[end+2:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+			return "", errors.New("unexpected content after closing quote")
+		}
+
+		return v[1 : end+1], nil
+	}
+
+	for i := 1; i < len(v); i++ {
+		if (v[i] == ';' || v[i] == '#') && (v[i-1] == ' ' || v[i-1] == '\t') {
+			return strings.TrimSpace(v[:i]), nil
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,103 @@
+package config_test
+
+import (
+	"strings"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func INIFile()", func() {
+	It("returns a bucket containing the values in the file", func() {
+		b, err := INIFile("./testdata/example.ini")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(b.Get("TITLE").String()).To(Equal("example"))
+		Expect(b.Get("DATABASE_HOST").String()).To(Equal("db.example.org"))
+		Expect(AsInt(b, "DATABASE_PORT")).To(Equal(5432))
+		Expect(b.Get("DATABASE_PORT").Source()).To(Equal("line 6 of ./testdata/example.ini"))
+	})
+
+	It("returns an error if the file does not exist", func() {
+		_, err := INIFile("./testdata/does-not-exist.ini")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("includes the path in syntax errors", func() {
+		_, err := INIFile("./testdata/example.toml")
+		Expect(err).To(MatchError(
+			`syntax error in ./testdata/example.toml on line 9: unexpected content after section header`,
+		))
+	})
+})
+
+var _ = Describe("func INIReader()", func() {
+	parse := func(s string, opts ...DocumentOption) Bucket {
+		b, err := INIReader(strings.NewReader(s), opts...)
+		Expect(err).ShouldNot(HaveOccurred())
+		return b
+	}
+
+	DescribeTable(
+		"it maps values to keys",
+		func(content, key, expect string) {
+			b := parse(content)
+			Expect(b.Get(key).String()).To(Equal(expect))
+		},
+		Entry("equals separator", "key = value", "KEY", "value"),
+		Entry("colon separator", "key: value", "KEY", "value"),
+		Entry("value containing separators", "key = a=b:c", "KEY", "a=b:c"),
+		Entry("inline comment", "key = value ; comment", "KEY", "value"),
+		Entry("value containing comment characters", "key = a;b#c", "KEY", "a;b#c"),
+		Entry("double-quoted", `key = "  value ; not a comment  "`, "KEY", "  value ; not a comment  "),
+		Entry("single-quoted", `key = '  value  ' # comment`, "KEY", "  value  "),
+		Entry("section", "[database]\nhost = db", "DATABASE_HOST", "db"),
+		Entry("section with whitespace", "[ database ] ; comment\nhost = db", "DATABASE_HOST", "db"),
+		Entry("repeated section", "[a]\nb = 1\n[c]\nd = 2\n[a]\ne = 3", "A_E", "3"),
+		Entry("CRLF line endings", "[a]\r\nb = 1\r\n", "A_B", "1"),
+	)
+
+	It("ignores comments and blank lines", func() {
+		b := parse("; comment\n\n   \n# comment\nkey = value\n")
+
+		var keys []string
+		b.Each(func(k string, _ Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+
+		Expect(keys).To(ConsistOf("KEY"))
+	})
+
+	It("treats empty values as undefined", func() {
+		b := parse("key =")
+		v := b.Get("KEY")
+		Expect(v.IsZero()).To(BeTrue())
+	})
+
+	It("supports a custom key separator and case", func() {
+		b := parse(
+			"[http]\ntimeout = 5s",
+			DocumentKeySeparator("."),
+			DocumentKeyCase(strings.ToLower),
+		)
+		Expect(b.Get("http.timeout").String()).To(Equal("5s"))
+	})
+
+	DescribeTable(
+		"it returns a syntax error",
+		func(content, expect string) {
+			_, err := INIReader(strings.NewReader(content))
+			Expect(err).To(MatchError(expect))
+		},
+		Entry("missing separator", "a = 1\nkey value", `syntax error on line 2: expected '=' after key value`),
+		Entry("missing key", "\n= 1", `syntax error on line 2: expected a key`),
+		Entry("unterminated section header", "[a\nb = 1", `syntax error on line 1: expected ']' after section name`),
+		Entry("empty section name", "[ ]", `syntax error on line 1: expected a section name`),
+		Entry("content after section header", "[a] b", `syntax error on line 1: unexpected content after section header`),
+		Entry("unterminated quote", `key = "value`, `syntax error on line 1: unterminated quoted value`),
+		Entry("content after quote", `key = "a" b`, `syntax error on line 1: unexpected content after closing quote`),
+		Entry("duplicate key", "[a]\nb = 1\nb = 2", `syntax error on line 3: A_B is already defined on line 2`),
+	)
+})
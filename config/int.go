@@ -17,13 +17,31 @@ const (
 // AsInt returns the int representation of the value associated with k or panics
 // if unable to do so.
 func AsInt(b Bucket, k string) int {
-	return int(asInt(b, k, 0, MinInt, MaxInt))
+	return int(asInt(b, k, accessor{"AsInt", false}, 0, MinInt, MaxInt))
+}
+
+// TryAsInt returns the int representation of the value associated with k, or an
+// error if unable to do so.
+func TryAsInt(b Bucket, k string) (int, error) {
+	return try(func() int {
+		return int(asInt(b, k, accessor{"TryAsInt", false}, 0, MinInt, MaxInt))
+	})
 }
 
 // AsIntDefault returns the int representation of the value associated with k,
 // or the default value v if k is undefined.
 func AsIntDefault(b Bucket, k string, v int) int {
-	return int(asIntDefault(b, k, 0, int64(v), MinInt, MaxInt))
+	return int(asIntDefault(b, k, accessor{"AsIntDefault", false}, 0, int64(v), MinInt, MaxInt))
+}
+
+// TryAsIntDefault returns the int representation of the value associated with
+// k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsIntDefault(b Bucket, k string, v int) (int, error) {
+	return try(func() int {
+		return int(asIntDefault(b, k, accessor{"TryAsIntDefault", false}, 0, int64(v), MinInt, MaxInt))
+	})
 }
 
 // AsIntBetween returns the int representation of the value associated with k or
@@ -31,7 +49,17 @@ func AsIntDefault(b Bucket, k string, v int) int {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsIntBetween(b Bucket, k string, min, max int) int {
-	return int(asInt(b, k, 0, int64(min), int64(max)))
+	return int(asInt(b, k, accessor{"AsIntBetween", true}, 0, int64(min), int64(max)))
+}
+
+// TryAsIntBetween returns the int representation of the value associated with
+// k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsIntBetween(b Bucket, k string, min, max int) (int, error) {
+	return try(func() int {
+		return int(asInt(b, k, accessor{"TryAsIntBetween", true}, 0, int64(min), int64(max)))
+	})
 }
 
 // AsIntDefaultBetween returns the int representation of the value associated
@@ -39,19 +67,47 @@ func AsIntBetween(b Bucket, k string, min, max int) int {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsIntDefaultBetween(b Bucket, k string, v, min, max int) int {
-	return int(asIntDefault(b, k, 0, int64(v), int64(min), int64(max)))
+	return int(asIntDefault(b, k, accessor{"AsIntDefaultBetween", true}, 0, int64(v), int64(min), int64(max)))
+}
+
+// TryAsIntDefaultBetween returns the int representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsIntDefaultBetween(b Bucket, k string, v, min, max int) (int, error) {
+	return try(func() int {
+		return int(asIntDefault(b, k, accessor{"TryAsIntDefaultBetween", true}, 0, int64(v), int64(min), int64(max)))
+	})
 }
 
 // AsInt8 returns the int8 representation of the value associated with k or
 // panics if unable to do so.
 func AsInt8(b Bucket, k string) int8 {
-	return int8(asInt(b, k, 8, math.MinInt8, math.MaxInt8))
+	return int8(asInt(b, k, accessor{"AsInt8", false}, 8, math.MinInt8, math.MaxInt8))
+}
+
+// TryAsInt8 returns the int8 representation of the value associated with k, or
+// an error if unable to do so.
+func TryAsInt8(b Bucket, k string) (int8, error) {
+	return try(func() int8 {
+		return int8(asInt(b, k, accessor{"TryAsInt8", false}, 8, math.MinInt8, math.MaxInt8))
+	})
 }
 
 // AsInt8Default returns the int8 representation of the value associated with k,
 // or the default value v if k is undefined.
 func AsInt8Default(b Bucket, k string, v int8) int8 {
-	return int8(asIntDefault(b, k, 8, int64(v), math.MinInt8, math.MaxInt8))
+	return int8(asIntDefault(b, k, accessor{"AsInt8Default", false}, 8, int64(v), math.MinInt8, math.MaxInt8))
+}
+
+// TryAsInt8Default returns the int8 representation of the value associated with
+// k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsInt8Default(b Bucket, k string, v int8) (int8, error) {
+	return try(func() int8 {
+		return int8(asIntDefault(b, k, accessor{"TryAsInt8Default", false}, 8, int64(v), math.MinInt8, math.MaxInt8))
+	})
 }
 
 // AsInt8Between returns the int8 representation of the value associated with k
@@ -59,7 +115,17 @@ func AsInt8Default(b Bucket, k string, v int8) int8 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsInt8Between(b Bucket, k string, min, max int8) int8 {
-	return int8(asInt(b, k, 8, int64(min), int64(max)))
+	return int8(asInt(b, k, accessor{"AsInt8Between", true}, 8, int64(min), int64(max)))
+}
+
+// TryAsInt8Between returns the int8 representation of the value associated with
+// k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsInt8Between(b Bucket, k string, min, max int8) (int8, error) {
+	return try(func() int8 {
+		return int8(asInt(b, k, accessor{"TryAsInt8Between", true}, 8, int64(min), int64(max)))
+	})
 }
 
 // AsInt8DefaultBetween returns the int8 representation of the value associated with
@@ -67,19 +133,47 @@ func AsInt8Between(b Bucket, k string, min, max int8) int8 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsInt8DefaultBetween(b Bucket, k string, v, min, max int8) int8 {
-	return int8(asIntDefault(b, k, 8, int64(v), int64(min), int64(max)))
+	return int8(asIntDefault(b, k, accessor{"AsInt8DefaultBetween", true}, 8, int64(v), int64(min), int64(max)))
+}
+
+// TryAsInt8DefaultBetween returns the int8 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsInt8DefaultBetween(b Bucket, k string, v, min, max int8) (int8, error) {
+	return try(func() int8 {
+		return int8(asIntDefault(b, k, accessor{"TryAsInt8DefaultBetween", true}, 8, int64(v), int64(min), int64(max)))
+	})
 }
 
 // AsInt16 returns the int16 representation of the value associated with k or
 // panics if unable to do so.
 func AsInt16(b Bucket, k string) int16 {
-	return int16(asInt(b, k, 16, math.MinInt16, math.MaxInt16))
+	return int16(asInt(b, k, accessor{"AsInt16", false}, 16, math.MinInt16, math.MaxInt16))
+}
+
+// TryAsInt16 returns the int16 representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsInt16(b Bucket, k string) (int16, error) {
+	return try(func() int16 {
+		return int16(asInt(b, k, accessor{"TryAsInt16", false}, 16, math.MinInt16, math.MaxInt16))
+	})
 }
 
 // AsInt16Default returns the int16 representation of the value associated with k,
 // or the default value v if k is undefined.
 func AsInt16Default(b Bucket, k string, v int16) int16 {
-	return int16(asIntDefault(b, k, 16, int64(v), math.MinInt16, math.MaxInt16))
+	return int16(asIntDefault(b, k, accessor{"AsInt16Default", false}, 16, int64(v), math.MinInt16, math.MaxInt16))
+}
+
+// TryAsInt16Default returns the int16 representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsInt16Default(b Bucket, k string, v int16) (int16, error) {
+	return try(func() int16 {
+		return int16(asIntDefault(b, k, accessor{"TryAsInt16Default", false}, 16, int64(v), math.MinInt16, math.MaxInt16))
+	})
 }
 
 // AsInt16Between returns the int16 representation of the value associated with k
@@ -87,7 +181,17 @@ func AsInt16Default(b Bucket, k string, v int16) int16 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsInt16Between(b Bucket, k string, min, max int16) int16 {
-	return int16(asInt(b, k, 16, int64(min), int64(max)))
+	return int16(asInt(b, k, accessor{"AsInt16Between", true}, 16, int64(min), int64(max)))
+}
+
+// TryAsInt16Between returns the int16 representation of the value associated
+// with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsInt16Between(b Bucket, k string, min, max int16) (int16, error) {
+	return try(func() int16 {
+		return int16(asInt(b, k, accessor{"TryAsInt16Between", true}, 16, int64(min), int64(max)))
+	})
 }
 
 // AsInt16DefaultBetween returns the int16 representation of the value associated with
@@ -95,19 +199,47 @@ func AsInt16Between(b Bucket, k string, min, max int16) int16 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsInt16DefaultBetween(b Bucket, k string, v, min, max int16) int16 {
-	return int16(asIntDefault(b, k, 16, int64(v), int64(min), int64(max)))
+	return int16(asIntDefault(b, k, accessor{"AsInt16DefaultBetween", true}, 16, int64(v), int64(min), int64(max)))
+}
+
+// TryAsInt16DefaultBetween returns the int16 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsInt16DefaultBetween(b Bucket, k string, v, min, max int16) (int16, error) {
+	return try(func() int16 {
+		return int16(asIntDefault(b, k, accessor{"TryAsInt16DefaultBetween", true}, 16, int64(v), int64(min), int64(max)))
+	})
 }
 
 // AsInt32 returns the int32 representation of the value associated with k or
 // panics if unable to do so.
 func AsInt32(b Bucket, k string) int32 {
-	return int32(asInt(b, k, 32, math.MinInt32, math.MaxInt32))
+	return int32(asInt(b, k, accessor{"AsInt32", false}, 32, math.MinInt32, math.MaxInt32))
+}
+
+// TryAsInt32 returns the int32 representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsInt32(b Bucket, k string) (int32, error) {
+	return try(func() int32 {
+		return int32(asInt(b, k, accessor{"TryAsInt32", false}, 32, math.MinInt32, math.MaxInt32))
+	})
 }
 
 // AsInt32Default returns the int32 representation of the value associated with k,
 // or the default value v if k is undefined.
 func AsInt32Default(b Bucket, k string, v int32) int32 {
-	return int32(asIntDefault(b, k, 32, int64(v), math.MinInt32, math.MaxInt32))
+	return int32(asIntDefault(b, k, accessor{"AsInt32Default", false}, 32, int64(v), math.MinInt32, math.MaxInt32))
+}
+
+// TryAsInt32Default returns the int32 representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsInt32Default(b Bucket, k string, v int32) (int32, error) {
+	return try(func() int32 {
+		return int32(asIntDefault(b, k, accessor{"TryAsInt32Default", false}, 32, int64(v), math.MinInt32, math.MaxInt32))
+	})
 }
 
 // AsInt32Between returns the int32 representation of the value associated with k
@@ -115,7 +247,17 @@ func AsInt32Default(b Bucket, k string, v int32) int32 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsInt32Between(b Bucket, k string, min, max int32) int32 {
-	return int32(asInt(b, k, 32, int64(min), int64(max)))
+	return int32(asInt(b, k, accessor{"AsInt32Between", true}, 32, int64(min), int64(max)))
+}
+
+// TryAsInt32Between returns the int32 representation of the value associated
+// with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsInt32Between(b Bucket, k string, min, max int32) (int32, error) {
+	return try(func() int32 {
+		return int32(asInt(b, k, accessor{"TryAsInt32Between", true}, 32, int64(min), int64(max)))
+	})
 }
 
 // AsInt32DefaultBetween returns the int32 representation of the value associated with
@@ -123,19 +265,47 @@ func AsInt32Between(b Bucket, k string, min, max int32) int32 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsInt32DefaultBetween(b Bucket, k string, v, min, max int32) int32 {
-	return int32(asIntDefault(b, k, 32, int64(v), int64(min), int64(max)))
+	return int32(asIntDefault(b, k, accessor{"AsInt32DefaultBetween", true}, 32, int64(v), int64(min), int64(max)))
+}
+
+// TryAsInt32DefaultBetween returns the int32 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsInt32DefaultBetween(b Bucket, k string, v, min, max int32) (int32, error) {
+	return try(func() int32 {
+		return int32(asIntDefault(b, k, accessor{"TryAsInt32DefaultBetween", true}, 32, int64(v), int64(min), int64(max)))
+	})
 }
 
 // AsInt64 returns the int64 representation of the value associated with k or
 // panics if unable to do so.
 func AsInt64(b Bucket, k string) int64 {
-	return asInt(b, k, 64, math.MinInt64, math.MaxInt64)
+	return asInt(b, k, accessor{"AsInt64", false}, 64, math.MinInt64, math.MaxInt64)
+}
+
+// TryAsInt64 returns the int64 representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsInt64(b Bucket, k string) (int64, error) {
+	return try(func() int64 {
+		return asInt(b, k, accessor{"TryAsInt64", false}, 64, math.MinInt64, math.MaxInt64)
+	})
 }
 
 // AsInt64Default returns the int64 representation of the value associated with k,
 // or the default value v if k is undefined.
 func AsInt64Default(b Bucket, k string, v int64) int64 {
-	return asIntDefault(b, k, 64, v, math.MinInt64, math.MaxInt64)
+	return asIntDefault(b, k, accessor{"AsInt64Default", false}, 64, v, math.MinInt64, math.MaxInt64)
+}
+
+// TryAsInt64Default returns the int64 representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsInt64Default(b Bucket, k string, v int64) (int64, error) {
+	return try(func() int64 {
+		return asIntDefault(b, k, accessor{"TryAsInt64Default", false}, 64, v, math.MinInt64, math.MaxInt64)
+	})
 }
 
 // AsInt64Between returns the int64 representation of the value associated with k
@@ -143,7 +313,17 @@ func AsInt64Default(b Bucket, k string, v int64) int64 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsInt64Between(b Bucket, k string, min, max int64) int64 {
-	return asInt(b, k, 64, min, max)
+	return asInt(b, k, accessor{"AsInt64Between", true}, 64, min, max)
+}
+
+// TryAsInt64Between returns the int64 representation of the value associated
+// with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsInt64Between(b Bucket, k string, min, max int64) (int64, error) {
+	return try(func() int64 {
+		return asInt(b, k, accessor{"TryAsInt64Between", true}, 64, min, max)
+	})
 }
 
 // AsInt64DefaultBetween returns the int64 representation of the value associated with
@@ -151,22 +331,35 @@ func AsInt64Between(b Bucket, k string, min, max int64) int64 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsInt64DefaultBetween(b Bucket, k string, v, min, max int64) int64 {
-	return asIntDefault(b, k, 64, v, min, max)
+	return asIntDefault(b, k, accessor{"AsInt64DefaultBetween", true}, 64, v, min, max)
+}
+
+// TryAsInt64DefaultBetween returns the int64 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsInt64DefaultBetween(b Bucket, k string, v, min, max int64) (int64, error) {
+	return try(func() int64 {
+		return asIntDefault(b, k, accessor{"TryAsInt64DefaultBetween", true}, 64, v, min, max)
+	})
 }
 
 func tryAsInt(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	min, max int64,
 ) (int64, bool) {
-	x := b.Get(k)
+	annotateRange(b, k, a, min, max)
+
+	x := access(b, k, a)
 
 	if x.IsZero() {
 		return 0, false
 	}
 
-	s := mustAsString(k, x)
+	s := mustAsString(qualify(b, k), x)
 	v, err := strconv.ParseInt(
 		s,
 		10,
@@ -177,39 +370,44 @@ func tryAsInt(
 	}
 
 	panic(InvalidValue{
-		k,
-		s,
-		fmt.Sprintf(`expected an integer between %d and %d (inclusive)`,
+		Key:   qualify(b, k),
+		Value: s,
+		Explanation: fmt.Sprintf(`expected an integer between %d and %d (inclusive)`,
 			min,
 			max,
 		),
+		Source: x.Source(),
 	})
 }
 
 func asInt(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	min, max int64,
 ) int64 {
-	if v, ok := tryAsInt(b, k, bitSize, min, max); ok {
+	if v, ok := tryAsInt(b, k, a, bitSize, min, max); ok {
 		return v
 	}
 
-	panic(NotDefined{k})
+	panic(notDefined(b, k))
 }
 
 func asIntDefault(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	d, min, max int64,
 ) int64 {
+	annotateDefault(b, k, a, d)
+
 	if min > d || d > max {
 		panic(InvalidDefaultValue{
-			k,
-			fmt.Sprintf(`%d`, d),
-			fmt.Sprintf(
+			Key:          qualify(b, k),
+			DefaultValue: fmt.Sprintf(`%d`, d),
+			Explanation: fmt.Sprintf(
 				`expected an integer between %d and %d (inclusive)`,
 				min,
 				max,
@@ -217,7 +415,7 @@ func asIntDefault(
 		})
 	}
 
-	if v, ok := tryAsInt(b, k, bitSize, min, max); ok {
+	if v, ok := tryAsInt(b, k, a, bitSize, min, max); ok {
 		return v
 	}
 
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// JSONFile returns a Bucket that produces configuration values from the JSON
+// document at path p.
+//
+// The document must contain a single JSON object. Nested objects are
+// flattened, such that the "timeout" key within the "http" object is mapped to
+// the HTTP_TIMEOUT bucket key. The way in which keys are mapped can be changed
+// using the DocumentKeySeparator() and DocumentKeyCase() options.
+//
+// Scalar values are represented as strings, such that they can be parsed by
+// any of the As*() functions. Null values and empty strings are treated as
+// undefined.
+//
+// Arrays of scalars are represented as a single comma-separated value that can
+// be parsed by AsStringSlice() and the other list functions. Arrays of objects
+// or arrays are flattened into one key per element, with the (0-based) index of
+// the element as the last part of the key, such as SERVERS_0_HOST.
+//
+// It returns a SyntaxError if the document is not well-formed, or if two keys
+// in the document map to the same bucket key.
+func JSONFile(p string, opts ...DocumentOption) (Bucket, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseJSON(p, f, opts)
+}
+
+// JSONReader returns a Bucket that produces configuration values from the JSON
+// document in r.
+//
+// See JSONFile() for a description of how the document is mapped to keys.
+func JSONReader(r io.Reader, opts ...DocumentOption) (Bucket, error) {
+	return parseJSON("", r, opts)
+}
+
+// parseJSON parses the JSON document in r and returns a bucket containing its
+// values.
+//
+// p is the path to the file, used only for error reporting.
+func parseJSON(p string, r io.Reader, opts []DocumentOption) (Bucket, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &jsonParser{
+		path:   p,
+		data:   buf,
+		dec:    json.NewDecoder(bytes.NewReader(buf)),
+		lineNo: 1,
+	}
+	parser.dec.UseNumber()
+
+	root, err := parser.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return flattenDocument(p, root, opts)
+}
+
+// jsonParser parses a JSON document into a tree of docNode values.
+type jsonParser struct {
+	path string
+	data []byte
+	dec  *json.Decoder
+
+	// offset and lineNo are the offset and line number of the most recently
+	// consumed token, used to count lines incrementally.
+	offset int64
+	lineNo int
+}
+
+// parse returns the root node of the document.
+func (p *jsonParser) parse() (docNode, error) {
+	tok, err := p.next()
+	if err == io.EOF {
+		return docNode{Kind: docObject}, nil
+	} else if err != nil {
+		return docNode{}, err
+	}
+
+	if tok != json.Delim('{') {
+		return docNode{}, p.syntaxError("expected the document to be a JSON object")
+	}
+
+	root, err := p.parseObject()
+	if err != nil {
+		return docNode{}, err
+	}
+
+	if _, err := p.next(); err != io.EOF {
+		if err != nil {
+			return docNode{}, err
+		}
+		return docNode{}, p.syntaxError("unexpected content after the JSON object")
+	}
+
+	return root, nil
+}
+
+// parseValue returns the node that begins with tok.
+func (p *jsonParser) parseValue(tok json.Token) (docNode, error) {
+	line := p.line()
+
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			return p.parseObject()
+		}
+		return p.parseArray()
+	case string:
+		return docNode{Line: line, Kind: docScalar, Scalar: v}, nil
+	case json.Number:
+		return docNode{Line: line, Kind: docScalar, Scalar: v.String()}, nil
+	case bool:
+		return docNode{Line: line, Kind: docScalar, Scalar: fmt.Sprint(v)}, nil
+	default:
+		return docNode{Line: line, Kind: docNull}, nil
+	}
+}
+
+// parseObject parses the members of an object. The opening brace must already
+// have been consumed.
+func (p *jsonParser) parseObject() (docNode, error) {
+	n := docNode{
+		Line: p.line(),
+		Kind: docObject,
+	}
+
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return docNode{}, p.unexpectedEOF(err)
+		}
+
+		if tok == json.Delim('}') {
+			return n, nil
+		}
+
+		name := tok.(string) // the decoder guarantees that keys are strings
+
+		tok, err = p.next()
+		if err != nil {
+			return docNode{}, p.unexpectedEOF(err)
+		}
+
+		v, err := p.parseValue(tok)
+		if err != nil {
+			return docNode{}, err
+		}
+
+		n.Members = append(n.Members, docMember{name, v})
+	}
+}
+
+// parseArray parses the elements of an array. The opening bracket must
+// already have been consumed.
+func (p *jsonParser) parseArray() (docNode, error) {
+	n := docNode{
+		Line: p.line(),
+		Kind: docArray,
+	}
+
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return docNode{}, p.unexpectedEOF(err)
+		}
+
+		if tok == json.Delim(']') {
+			return n, nil
+		}
+
+		v, err := p.parseValue(tok)
+		if err != nil {
+			return docNode{}, err
+		}
+
+		n.Elements = append(n.Elements, v)
+	}
+}
+
+// next returns the next token in the document.
+//
+// It returns io.EOF if there are no more tokens, or a SyntaxError if the
+// document is not well-formed.
+func (p *jsonParser) next() (json.Token, error) {
+	tok, err := p.dec.Token()
+	if err == nil || err == io.EOF {
+		return tok, err
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil, SyntaxError{
+			Path:        p.path,
+			Line:        lineAt(p.data, syntaxErr.Offset),
+			Explanation: syntaxErr.Error(),
+		}
+	}
+
+	if err == io.ErrUnexpectedEOF {
+		return nil, p.unexpectedEOF(io.EOF)
+	}
+
+	return nil, p.syntaxError(err.Error())
+}
+
+// unexpectedEOF converts io.EOF to a SyntaxError, as it occurs when the
+// document ends within an object or array.
+func (p *jsonParser) unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return SyntaxError{
+			Path:        p.path,
+			Line:        lineAt(p.data, int64(len(p.data))),
+			Explanation: "unexpected end of file",
+		}
+	}
+
+	return err
+}
+
+// line returns the line number of the most recently consumed token.
+func (p *jsonParser) line() int {
+	offset := p.dec.InputOffset()
+
+	if offset > p.offset {
+		p.lineNo += bytes.Count(p.data[p.offset:offset], []byte("\n"))
+		p.offset = offset
+	}
+
+	return p.lineNo
+}
+
+// syntaxError returns a SyntaxError at the current position.
+func (p *jsonParser) syntaxError(explanation string) SyntaxError {
+	return SyntaxError{
+		Path:        p.path,
+		Line:        p.line(),
+		Explanation: explanation,
+	}
+}
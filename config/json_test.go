@@ -0,0 +1,120 @@
+package config_test
+
+import (
+	"strings"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func JSONFile()", func() {
+	It("returns a bucket containing the values in the file", func() {
+		b, err := JSONFile("./testdata/example.json")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(b.Get("EXAMPLE_CONFIG").String()).To(Equal("true"))
+		Expect(b.Get("EXAMPLE_CONFIG").Source()).To(Equal("line 1 of ./testdata/example.json"))
+	})
+
+	It("returns an error if the file does not exist", func() {
+		_, err := JSONFile("./testdata/does-not-exist.json")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("includes the path in syntax errors", func() {
+		_, err := JSONFile("./testdata/example.env")
+		Expect(err).To(MatchError(
+			`syntax error in ./testdata/example.env on line 1: invalid character '#' looking for beginning of value`,
+		))
+	})
+})
+
+var _ = Describe("func JSONReader()", func() {
+	parse := func(s string, opts ...DocumentOption) Bucket {
+		b, err := JSONReader(strings.NewReader(s), opts...)
+		Expect(err).ShouldNot(HaveOccurred())
+		return b
+	}
+
+	DescribeTable(
+		"it maps values to keys",
+		func(content, key, expect string) {
+			b := parse(content)
+			Expect(b.Get(key).String()).To(Equal(expect))
+		},
+		Entry("string", `{"key": "value"}`, "KEY", "value"),
+		Entry("integer", `{"key": 123}`, "KEY", "123"),
+		Entry("large integer", `{"key": 12345678901234567890}`, "KEY", "12345678901234567890"),
+		Entry("float", `{"key": 1.5e3}`, "KEY", "1.5e3"),
+		Entry("boolean", `{"key": false}`, "KEY", "false"),
+		Entry("nested object", `{"http": {"timeout": "5s"}}`, "HTTP_TIMEOUT", "5s"),
+		Entry("key containing punctuation", `{"http.read-timeout": "5s"}`, "HTTP_READ_TIMEOUT", "5s"),
+		Entry("array of scalars", `{"key": ["a", 1, true]}`, "KEY", "a,1,true"),
+		Entry("array of scalars that require quoting", `{"key": ["a,b", " c ", "d\"e"]}`, "KEY", `"a,b"," c ","d\"e"`),
+		Entry("array of objects", `{"servers": [{"host": "a"}, {"host": "b"}]}`, "SERVERS_1_HOST", "b"),
+		Entry("nested arrays", `{"key": [[1, 2], [3, 4]]}`, "KEY_1", "3,4"),
+	)
+
+	It("exposes arrays of scalars to the list functions", func() {
+		b := parse(`{"key": ["a,b", " c ", "d\"e", ""]}`)
+		Expect(AsStringSlice(b, "KEY")).To(Equal([]string{"a,b", " c ", `d"e`, ""}))
+
+		b = parse(`{"key": [1, 2, 3]}`)
+		Expect(AsIntSlice(b, "KEY")).To(Equal([]int{1, 2, 3}))
+	})
+
+	It("works with the typed functions", func() {
+		b := parse(`{"http": {"timeout": "5s", "port": 8080, "debug": true}}`)
+		Expect(AsDuration(b, "HTTP_TIMEOUT").String()).To(Equal("5s"))
+		Expect(AsInt(b, "HTTP_PORT")).To(Equal(8080))
+		Expect(AsBool(b, "HTTP_DEBUG")).To(BeTrue())
+	})
+
+	It("treats null values, empty strings and empty arrays as undefined", func() {
+		b := parse(`{"a": null, "b": "", "c": []}`)
+
+		var v Value
+		v = b.Get("A")
+		Expect(v.IsZero()).To(BeTrue())
+		v = b.Get("B")
+		Expect(v.IsZero()).To(BeTrue())
+		v = b.Get("C")
+		Expect(v.IsZero()).To(BeTrue())
+	})
+
+	It("returns an empty bucket if the document is empty", func() {
+		b := parse("  \n")
+		Expect(b.Each(func(string, Value) bool {
+			return false
+		})).To(BeTrue())
+	})
+
+	It("describes the line on which each value is defined", func() {
+		b := parse("{\n  \"a\": 1,\n  \"b\": {\n    \"c\": 2\n  }\n}")
+		Expect(b.Get("A").Source()).To(Equal("line 2"))
+		Expect(b.Get("B_C").Source()).To(Equal("line 4"))
+	})
+
+	It("supports a custom key separator and case", func() {
+		b := parse(
+			`{"http": {"timeout": "5s"}}`,
+			DocumentKeySeparator("."),
+			DocumentKeyCase(strings.ToLower),
+		)
+		Expect(b.Get("http.timeout").String()).To(Equal("5s"))
+	})
+
+	DescribeTable(
+		"it returns a syntax error",
+		func(content, expect string) {
+			_, err := JSONReader(strings.NewReader(content))
+			Expect(err).To(MatchError(expect))
+		},
+		Entry("invalid character", "{\n  \"a\": 1,\n  b\n}", `syntax error on line 3: invalid character 'b' looking for beginning of value`),
+		Entry("unterminated object", "{\n  \"a\": 1,\n", `syntax error on line 3: unexpected end of file`),
+		Entry("top-level array", `[1, 2]`, `syntax error on line 1: expected the document to be a JSON object`),
+		Entry("content after object", "{}\n{}", `syntax error on line 2: unexpected content after the JSON object`),
+		Entry("duplicate key", "{\n  \"http_timeout\": 1,\n  \"http\": {\"timeout\": 2}\n}", `syntax error on line 3: HTTP_TIMEOUT is already defined on line 2`),
+	)
+})
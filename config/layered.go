@@ -0,0 +1,125 @@
+package config
+
+// Layered returns a bucket that merges the key/value pairs of several other
+// buckets.
+//
+// The buckets are given in increasing order of precedence. That is, a value
+// in any given bucket shadows the values associated with the same key in the
+// buckets that precede it.
+func Layered(buckets ...Bucket) *LayeredBucket {
+	return &LayeredBucket{buckets}
+}
+
+// LayeredBucket is an implementation of Bucket that merges the key/value pairs
+// of several other buckets, known as layers.
+type LayeredBucket struct {
+	layers []Bucket
+}
+
+// Get returns the value associated with the given key.
+//
+// It returns the value from the highest-precedence layer that defines k. If
+// they key is not defined in any layer, it returns a zero-value.
+func (b *LayeredBucket) Get(k string) Value {
+	for i := len(b.layers) - 1; i >= 0; i-- {
+		if x := b.layers[i].Get(k); !x.IsZero() {
+			return x
+		}
+	}
+
+	return Value{}
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// It returns the value from the highest-precedence layer that defines k. If
+// the key is not defined in any layer, it returns a value with the content of
+// v.
+func (b *LayeredBucket) GetDefault(k string, v string) Value {
+	x := b.Get(k)
+
+	if x.IsZero() {
+		return String(v)
+	}
+
+	return x
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// Each key that is defined in any layer is visited exactly once, with the
+// value from the highest-precedence layer that defines it.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+func (b *LayeredBucket) Each(fn EachFunc) bool {
+	seen := map[string]struct{}{}
+
+	for i := len(b.layers) - 1; i >= 0; i-- {
+		ok := b.layers[i].Each(
+			func(k string, v Value) bool {
+				if v.IsZero() {
+					return true
+				}
+
+				if _, ok := seen[k]; ok {
+					return true
+				}
+
+				seen[k] = struct{}{}
+
+				return fn(k, v)
+			},
+		)
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// qualifiedKey returns the key used to identify k in the layer that supplies
+// its value.
+//
+// If k is undefined in every layer, it returns the key used to identify k in
+// the highest-precedence layer that qualifies it, such that errors refer to
+// the key as the user would need to define it.
+func (b *LayeredBucket) qualifiedKey(k string) string {
+	for i := len(b.layers) - 1; i >= 0; i-- {
+		if x := b.layers[i].Get(k); !x.IsZero() {
+			return qualify(b.layers[i], k)
+		}
+	}
+
+	for i := len(b.layers) - 1; i >= 0; i-- {
+		if q := qualify(b.layers[i], k); q != k {
+			return q
+		}
+	}
+
+	return k
+}
+
+// Layers returns the indices of the layers that define k, in order of
+// decreasing precedence.
+//
+// The first element, if any, is the index of the layer that supplies the value
+// returned by Get(k). The remaining elements are the indices of the layers that
+// it shadows.
+//
+// Indices refer to the order in which the buckets were passed to Layered().
+func (b *LayeredBucket) Layers(k string) []int {
+	var indices []int
+
+	for i := len(b.layers) - 1; i >= 0; i-- {
+		if x := b.layers[i].Get(k); !x.IsZero() {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
@@ -0,0 +1,98 @@
+package config_test
+
+import (
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("type LayeredBucket", func() {
+	var bucket *LayeredBucket
+
+	BeforeEach(func() {
+		bucket = Layered(
+			Map{
+				"<key-1>": String("<value-1a>"),
+				"<key-2>": String("<value-2a>"),
+			},
+			Map{
+				"<key-2>": String("<value-2b>"),
+				"<key-3>": Value{},
+			},
+			Map{
+				"<key-1>": String("<value-1c>"),
+			},
+		)
+	})
+
+	Describe("func Get()", func() {
+		It("returns the value from the highest-precedence layer", func() {
+			v := bucket.Get("<key-1>")
+			Expect(v).To(Equal(String("<value-1c>")))
+
+			v = bucket.Get("<key-2>")
+			Expect(v).To(Equal(String("<value-2b>")))
+		})
+
+		It("returns the zero-value if the key is undefined in every layer", func() {
+			v := bucket.Get("<undefined>")
+			Expect(v.IsZero()).To(BeTrue())
+
+			v = bucket.Get("<key-3>")
+			Expect(v.IsZero()).To(BeTrue())
+		})
+	})
+
+	Describe("func GetDefault()", func() {
+		It("returns the value from the highest-precedence layer", func() {
+			v := bucket.GetDefault("<key-2>", "<default>")
+			Expect(v).To(Equal(String("<value-2b>")))
+		})
+
+		It("returns the default value if the key is undefined in every layer", func() {
+			v := bucket.GetDefault("<undefined>", "<default>")
+			Expect(v).To(Equal(String("<default>")))
+		})
+	})
+
+	Describe("func Each()", func() {
+		It("visits each defined key exactly once with the winning value", func() {
+			calls := map[string][]Value{}
+
+			fn := func(k string, v Value) bool {
+				calls[k] = append(calls[k], v)
+				return true
+			}
+
+			Expect(bucket.Each(fn)).To(BeTrue())
+			Expect(calls).To(Equal(map[string][]Value{
+				"<key-1>": {String("<value-1c>")},
+				"<key-2>": {String("<value-2b>")},
+			}))
+		})
+
+		It("stops iterating if the function returns false", func() {
+			count := 0
+
+			fn := func(k string, v Value) bool {
+				count++
+				return false
+			}
+
+			Expect(bucket.Each(fn)).To(BeFalse())
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	Describe("func Layers()", func() {
+		It("returns the indices of the layers that define the key", func() {
+			Expect(bucket.Layers("<key-1>")).To(Equal([]int{2, 0}))
+			Expect(bucket.Layers("<key-2>")).To(Equal([]int{1, 0}))
+		})
+
+		It("returns an empty slice if the key is undefined in every layer", func() {
+			Expect(bucket.Layers("<key-3>")).To(BeEmpty())
+			Expect(bucket.Layers("<undefined>")).To(BeEmpty())
+		})
+	})
+})
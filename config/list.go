@@ -0,0 +1,395 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ListOption is an option that changes the behavior of the functions that
+// parse lists and maps, such as AsStringSlice() and AsStringMap().
+type ListOption func(*listOptions)
+
+// listOptions is the set of options applied by the list and map functions.
+type listOptions struct {
+	sep      string
+	kvSep    string
+	min, max int
+}
+
+// ListSeparator returns an option that sets the separator used between the
+// elements of a list, or the key/value pairs of a map.
+//
+// The default separator is a comma.
+func ListSeparator(sep string) ListOption {
+	if sep == "" {
+		panic("list separator must not be empty")
+	}
+
+	return func(o *listOptions) {
+		o.sep = sep
+	}
+}
+
+// MapKeyValueSeparator returns an option that sets the separator used between
+// the key and the value of each element of a map.
+//
+// The default separator is an equals sign.
+func MapKeyValueSeparator(sep string) ListOption {
+	if sep == "" {
+		panic("key/value separator must not be empty")
+	}
+
+	return func(o *listOptions) {
+		o.kvSep = sep
+	}
+}
+
+// ListLength returns an option that requires a list or map to have between min
+// and max elements (inclusive).
+func ListLength(min, max int) ListOption {
+	return func(o *listOptions) {
+		o.min = min
+		o.max = max
+	}
+}
+
+// AsStringSlice returns the []string representation of the value associated
+// with k or panics if unable to do so.
+//
+// Elements are separated by commas, unless a different separator is specified
+// using the ListSeparator() option. Leading and trailing whitespace is removed
+// from each element. Elements may be enclosed in double quotes in order to
+// include the separator or whitespace, within which \" and \\ are the only
+// escape sequences.
+func AsStringSlice(b Bucket, k string, opts ...ListOption) []string {
+	return asSlice[string](b, k, accessor{"AsStringSlice", false}, nil, false, opts)
+}
+
+// TryAsStringSlice returns the []string representation of the value associated
+// with k, or an error if unable to do so.
+//
+// See AsStringSlice() for a description of the list syntax.
+func TryAsStringSlice(b Bucket, k string, opts ...ListOption) ([]string, error) {
+	return try(func() []string {
+		return asSlice[string](b, k, accessor{"TryAsStringSlice", false}, nil, false, opts)
+	})
+}
+
+// AsStringSliceDefault returns the []string representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// See AsStringSlice() for a description of the list syntax.
+func AsStringSliceDefault(b Bucket, k string, v []string, opts ...ListOption) []string {
+	return asSlice(b, k, accessor{"AsStringSliceDefault", false}, v, true, opts)
+}
+
+// TryAsStringSliceDefault returns the []string representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsStringSliceDefault(b Bucket, k string, v []string, opts ...ListOption) ([]string, error) {
+	return try(func() []string {
+		return asSlice(b, k, accessor{"TryAsStringSliceDefault", false}, v, true, opts)
+	})
+}
+
+// AsIntSlice returns the []int representation of the value associated with k
+// or panics if unable to do so.
+//
+// See AsStringSlice() for a description of the list syntax.
+func AsIntSlice(b Bucket, k string, opts ...ListOption) []int {
+	return asSlice[int](b, k, accessor{"AsIntSlice", false}, nil, false, opts)
+}
+
+// TryAsIntSlice returns the []int representation of the value associated with
+// k, or an error if unable to do so.
+//
+// See AsStringSlice() for a description of the list syntax.
+func TryAsIntSlice(b Bucket, k string, opts ...ListOption) ([]int, error) {
+	return try(func() []int {
+		return asSlice[int](b, k, accessor{"TryAsIntSlice", false}, nil, false, opts)
+	})
+}
+
+// AsIntSliceDefault returns the []int representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// See AsStringSlice() for a description of the list syntax.
+func AsIntSliceDefault(b Bucket, k string, v []int, opts ...ListOption) []int {
+	return asSlice(b, k, accessor{"AsIntSliceDefault", false}, v, true, opts)
+}
+
+// TryAsIntSliceDefault returns the []int representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsIntSliceDefault(b Bucket, k string, v []int, opts ...ListOption) ([]int, error) {
+	return try(func() []int {
+		return asSlice(b, k, accessor{"TryAsIntSliceDefault", false}, v, true, opts)
+	})
+}
+
+// AsDurationSlice returns the []time.Duration representation of the value
+// associated with k or panics if unable to do so.
+//
+// See AsStringSlice() for a description of the list syntax. Each element is
+// specified using the syntax supported by time.ParseDuration.
+func AsDurationSlice(b Bucket, k string, opts ...ListOption) []time.Duration {
+	return asSlice[time.Duration](b, k, accessor{"AsDurationSlice", false}, nil, false, opts)
+}
+
+// TryAsDurationSlice returns the []time.Duration representation of the value
+// associated with k, or an error if unable to do so.
+//
+// See AsDurationSlice() for a description of the list syntax.
+func TryAsDurationSlice(b Bucket, k string, opts ...ListOption) ([]time.Duration, error) {
+	return try(func() []time.Duration {
+		return asSlice[time.Duration](b, k, accessor{"TryAsDurationSlice", false}, nil, false, opts)
+	})
+}
+
+// AsDurationSliceDefault returns the []time.Duration representation of the
+// value associated with k, or the default value v if k is undefined.
+//
+// See AsDurationSlice() for a description of the list syntax.
+func AsDurationSliceDefault(b Bucket, k string, v []time.Duration, opts ...ListOption) []time.Duration {
+	return asSlice(b, k, accessor{"AsDurationSliceDefault", false}, v, true, opts)
+}
+
+// TryAsDurationSliceDefault returns the []time.Duration representation of the
+// value associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsDurationSliceDefault(b Bucket, k string, v []time.Duration, opts ...ListOption) ([]time.Duration, error) {
+	return try(func() []time.Duration {
+		return asSlice(b, k, accessor{"TryAsDurationSliceDefault", false}, v, true, opts)
+	})
+}
+
+// AsURLSlice returns the []*url.URL representation of the value associated
+// with k or panics if unable to do so.
+//
+// See AsStringSlice() for a description of the list syntax.
+func AsURLSlice(b Bucket, k string, opts ...ListOption) []*url.URL {
+	return asSlice[*url.URL](b, k, accessor{"AsURLSlice", false}, nil, false, opts)
+}
+
+// TryAsURLSlice returns the []*url.URL representation of the value associated
+// with k, or an error if unable to do so.
+//
+// See AsStringSlice() for a description of the list syntax.
+func TryAsURLSlice(b Bucket, k string, opts ...ListOption) ([]*url.URL, error) {
+	return try(func() []*url.URL {
+		return asSlice[*url.URL](b, k, accessor{"TryAsURLSlice", false}, nil, false, opts)
+	})
+}
+
+// AsURLSliceDefault returns the []*url.URL representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// See AsStringSlice() for a description of the list syntax.
+func AsURLSliceDefault(b Bucket, k string, v []*url.URL, opts ...ListOption) []*url.URL {
+	return asSlice(b, k, accessor{"AsURLSliceDefault", false}, v, true, opts)
+}
+
+// TryAsURLSliceDefault returns the []*url.URL representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsURLSliceDefault(b Bucket, k string, v []*url.URL, opts ...ListOption) ([]*url.URL, error) {
+	return try(func() []*url.URL {
+		return asSlice(b, k, accessor{"TryAsURLSliceDefault", false}, v, true, opts)
+	})
+}
+
+// newListOptions returns the list options produced by applying opts to the
+// defaults.
+func newListOptions(opts []ListOption) listOptions {
+	o := listOptions{
+		sep:   ",",
+		kvSep: "=",
+		max:   MaxInt,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// lengthExplanation returns the explanation used when a list does not have
+// the number of elements required by o.
+func (o listOptions) lengthExplanation() string {
+	return fmt.Sprintf(
+		`expected between %d and %d elements (inclusive)`,
+		o.min,
+		o.max,
+	)
+}
+
+func asSlice[T any](
+	b Bucket,
+	k string,
+	a accessor,
+	d []T,
+	hasDefault bool,
+	opts []ListOption,
+) []T {
+	o := newListOptions(opts)
+
+	if hasDefault && (len(d) < o.min || len(d) > o.max) {
+		elements := make([]string, len(d))
+		for i, e := range d {
+			elements[i] = fmt.Sprint(e)
+		}
+
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: strings.Join(elements, o.sep),
+			Explanation:  o.lengthExplanation(),
+		})
+	}
+
+	x := access(b, k, a)
+
+	if x.IsZero() {
+		if hasDefault {
+			return d
+		}
+
+		panic(notDefined(b, k))
+	}
+
+	s := mustAsString(qualify(b, k), x)
+
+	elements, err := splitList(s, o.sep, -1)
+	if err == nil {
+		err = o.checkLength(elements)
+	}
+	if err != nil {
+		panic(InvalidValue{
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: err.Error(),
+			Source:      x.Source(),
+		})
+	}
+
+	parse := parserFor[T](b, k)
+	values := make([]T, len(elements))
+
+	for i, e := range elements {
+		v, err := unquoteElement(e)
+		if err == nil {
+			values[i], err = parse(v)
+		}
+
+		if err != nil {
+			panic(InvalidValue{
+				Key:         qualify(b, k),
+				Value:       s,
+				Explanation: fmt.Sprintf(`element %d (%#v) is invalid: %s`, i, strings.TrimSpace(e), err),
+				Source:      x.Source(),
+			})
+		}
+	}
+
+	return values
+}
+
+// checkLength returns an error if elements does not have the number of
+// elements required by o.
+func (o listOptions) checkLength(elements []string) error {
+	if len(elements) < o.min || len(elements) > o.max {
+		return errors.New(o.lengthExplanation())
+	}
+
+	return nil
+}
+
+// splitList splits s into at most n elements separated by sep.
+//
+// If n is negative there is no limit to the number of elements. Separators
+// within double-quoted sections are ignored. The quotes are retained so that
+// the element can be unquoted later by unquoteElement().
+//
+// An empty (or entirely whitespace) string produces no elements.
+func splitList(s, sep string, n int) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var (
+		elements []string
+		start    int
+		quoted   bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quoted:
+			i++ // skip the escaped character
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted &&
+			n != len(elements)+1 &&
+			strings.HasPrefix(s[i:], sep):
+			elements = append(elements, s[start:i])
+			i += len(sep) - 1
+			start = i + 1
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf(
+			`element %d has an unterminated quote`,
+			len(elements),
+		)
+	}
+
+	return append(elements, s[start:]), nil
+}
+
+// unquoteElement removes leading and trailing whitespace from an element
+// produced by splitList(), and removes the enclosing quotes, if present.
+func unquoteElement(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if !strings.HasPrefix(s, `"`) {
+		if strings.Contains(s, `"`) {
+			return "", errors.New(`quotes must enclose the entire element`)
+		}
+
+		return s, nil
+	}
+
+	var v strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i < len(s) && (s[i] == '"' || s[i] == '\\') {
+				v.WriteByte(s[i])
+			} else {
+				return "", errors.New(`quoted elements only support the \" and \\ escape sequences`)
+			}
+
+		case '"':
+			if i != len(s)-1 {
+				return "", errors.New(`quotes must enclose the entire element`)
+			}
+
+			return v.String(), nil
+
+		default:
+			v.WriteByte(s[i])
+		}
+	}
+
+	return "", errors.New(`unterminated quote`)
+}
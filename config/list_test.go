@@ -0,0 +1,137 @@
+package config_test
+
+import (
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func AsStringSlice()", func() {
+	DescribeTable(
+		"it returns a []string value",
+		func(value string, expect []string, opts ...ListOption) {
+			b := Map{"<key>": String(value)}
+
+			v := AsStringSlice(b, "<key>", opts...)
+			Expect(v).To(Equal(expect))
+		},
+		Entry("single element", "a", []string{"a"}),
+		Entry("multiple elements", "a,b,c", []string{"a", "b", "c"}),
+		Entry("surrounding whitespace", " a , b ", []string{"a", "b"}),
+		Entry("empty elements", "a,,b", []string{"a", "", "b"}),
+		Entry("empty value", "", []string{}),
+		Entry("quoted elements", `"a,b", " c ",""`, []string{"a,b", " c ", ""}),
+		Entry("escape sequences", `"a\"b\\c"`, []string{`a"b\c`}),
+		Entry("custom separator", "a:9092;b:9092", []string{"a:9092", "b:9092"}, ListSeparator(";")),
+		Entry("multi-character separator", "a::b", []string{"a", "b"}, ListSeparator("::")),
+	)
+
+	It("panics if the key is not defined", func() {
+		Expect(func() {
+			AsStringSlice(Map{}, "<key>")
+		}).To(PanicWith(NotDefined{Key: "<key>"}))
+	})
+
+	DescribeTable(
+		"it panics if the value is invalid",
+		func(value, explanation string) {
+			b := Map{"<key>": String(value)}
+
+			Expect(func() {
+				AsStringSlice(b, "<key>", ListLength(1, 3))
+			}).To(PanicWith(InvalidValue{
+				Key:         "<key>",
+				Value:       value,
+				Explanation: explanation,
+			}))
+		},
+		Entry("too few elements", "", `expected between 1 and 3 elements (inclusive)`),
+		Entry("too many elements", "a,b,c,d", `expected between 1 and 3 elements (inclusive)`),
+		Entry("unterminated quote", `a,"b`, `element 1 has an unterminated quote`),
+		Entry("partially quoted element", `a,b"c"`, `element 1 ("b\"c\"") is invalid: quotes must enclose the entire element`),
+		Entry("text after quoted element", `"a"b`, `element 0 ("\"a\"b") is invalid: quotes must enclose the entire element`),
+		Entry("unsupported escape sequence", `"a\n"`, `element 0 ("\"a\\n\"") is invalid: quoted elements only support the \" and \\ escape sequences`),
+	)
+})
+
+var _ = Describe("func AsStringSliceDefault()", func() {
+	It("returns the default value if the key is not defined", func() {
+		v := AsStringSliceDefault(Map{}, "<key>", []string{"a", "b"})
+		Expect(v).To(Equal([]string{"a", "b"}))
+	})
+
+	It("panics if the default value has an invalid length", func() {
+		Expect(func() {
+			AsStringSliceDefault(Map{}, "<key>", []string{"a", "b"}, ListLength(0, 1), ListSeparator(";"))
+		}).To(PanicWith(InvalidDefaultValue{
+			Key:          "<key>",
+			DefaultValue: "a;b",
+			Explanation:  `expected between 0 and 1 elements (inclusive)`,
+		}))
+	})
+})
+
+var _ = Describe("func AsIntSlice()", func() {
+	It("returns an []int value", func() {
+		b := Map{"<key>": String("1, -2, 3")}
+
+		v := AsIntSlice(b, "<key>")
+		Expect(v).To(Equal([]int{1, -2, 3}))
+	})
+
+	It("panics with the index of the invalid element", func() {
+		b := Map{"<key>": String("1,x,3")}
+
+		Expect(func() {
+			AsIntSlice(b, "<key>")
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "1,x,3",
+			Explanation: `element 1 ("x") is invalid: expected an integer between -9223372036854775808 and 9223372036854775807 (inclusive)`,
+		}))
+	})
+})
+
+var _ = Describe("func AsDurationSlice()", func() {
+	It("returns a []time.Duration value", func() {
+		b := Map{"<key>": String("1s,1m")}
+
+		v := AsDurationSlice(b, "<key>")
+		Expect(v).To(Equal([]time.Duration{time.Second, time.Minute}))
+	})
+
+	It("panics with the index of the invalid element", func() {
+		b := Map{"<key>": String("1s,<invalid>")}
+
+		Expect(func() {
+			AsDurationSlice(b, "<key>")
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "1s,<invalid>",
+			Explanation: `element 1 ("<invalid>") is invalid: expected a duration`,
+		}))
+	})
+})
+
+var _ = Describe("func AsURLSlice()", func() {
+	It("returns a []*url.URL value", func() {
+		b := Map{"<key>": String("http://a.example.org,http://b.example.org")}
+
+		v := AsURLSlice(b, "<key>")
+		Expect(v).To(HaveLen(2))
+		Expect(v[0].String()).To(Equal("http://a.example.org"))
+		Expect(v[1].String()).To(Equal("http://b.example.org"))
+	})
+})
+
+var _ = Describe("func TryAsIntSlice()", func() {
+	It("returns an error if the value is invalid", func() {
+		b := Map{"<key>": String("1,x")}
+
+		_, err := TryAsIntSlice(b, "<key>")
+		Expect(err).To(BeAssignableToTypeOf(InvalidValue{}))
+	})
+})
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Live is a handle to the value associated with a key in a Watchable bucket.
+//
+// It holds the most recent valid value, which is re-parsed each time the
+// bucket reports a change. Load() is safe for concurrent use and does not
+// parse the value, making it suitable for use on hot paths.
+type Live[T any] struct {
+	read    func() T
+	m       sync.Mutex // serializes reads, such that a stale value is never stored
+	value   atomic.Pointer[T]
+	onError atomic.Pointer[func(KeyError)]
+	cancel  func()
+}
+
+// NewLive returns a handle to the representation of the value associated with
+// k as type T.
+//
+// The value is parsed as per Get(). It panics if the current value is invalid.
+// If the value subsequently becomes invalid, Load() continues to return the
+// previous value and the error is passed to the function set by
+// Live.OnError().
+func NewLive[T any](b Watchable, k string, opts ...Option[T]) *Live[T] {
+	return newLive(b, k, func() T {
+		return Get(b, k, opts...)
+	})
+}
+
+// LiveDuration returns a handle to the time.Duration representation of the
+// value associated with k, or the default value v if k is undefined.
+//
+// The value is parsed as per AsDurationDefault(). See NewLive() for a
+// description of how changes to the value are handled.
+func LiveDuration(b Watchable, k string, v time.Duration) *Live[time.Duration] {
+	return newLive(b, k, func() time.Duration {
+		return AsDurationDefault(b, k, v)
+	})
+}
+
+// newLive returns a handle that uses read to obtain the value associated with
+// k whenever it changes.
+func newLive[T any](b Watchable, k string, read func() T) *Live[T] {
+	l := &Live[T]{
+		read: read,
+	}
+
+	// Subscribe before reading the initial value, such that a change that
+	// occurs in the meantime is not missed.
+	l.cancel = b.Subscribe(
+		[]string{k},
+		func(Value, Value) {
+			l.update()
+		},
+	)
+
+	ok := false
+	defer func() {
+		if !ok {
+			l.cancel()
+		}
+	}()
+
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	v := read()
+	l.value.Store(&v)
+	ok = true
+
+	return l
+}
+
+// Load returns the most recent valid value.
+func (l *Live[T]) Load() T {
+	return *l.value.Load()
+}
+
+// OnError sets a function that is called when the value changes to one that
+// is invalid.
+//
+// The handle continues to return the previous value from Load() until a valid
+// value is available.
+func (l *Live[T]) OnError(fn func(KeyError)) {
+	l.onError.Store(&fn)
+}
+
+// Close stops tracking changes to the value.
+//
+// Load() continues to return the most recent valid value.
+func (l *Live[T]) Close() {
+	l.cancel()
+}
+
+// update re-parses the value, retaining the previous value if the new value is
+// invalid.
+func (l *Live[T]) update() {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	v, err := try(l.read)
+	if err != nil {
+		if fn := l.onError.Load(); fn != nil {
+			(*fn)(err.(KeyError))
+		}
+		return
+	}
+
+	l.value.Store(&v)
+}
@@ -0,0 +1,166 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// racyBucket is a Watchable that changes the value of a key while a
+// subscription is being made, before the subscription takes effect.
+type racyBucket struct {
+	Map
+	key, value string
+}
+
+func (b racyBucket) Subscribe([]string, func(old, new Value)) func() {
+	b.Map[b.key] = String(b.value)
+	return func() {}
+}
+
+var _ = Describe("type Live", func() {
+	var (
+		dir    string
+		bucket *WatchedBucket
+	)
+
+	// reload replaces the content of the dotenv file and reloads the bucket.
+	reload := func(content string) {
+		err := os.WriteFile(filepath.Join(dir, "app.env"), []byte(content), 0600)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = bucket.Reload()
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = os.WriteFile(filepath.Join(dir, "app.env"), []byte("TIMEOUT=10s\nLIMIT=5\n"), 0600)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		bucket, err = WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(time.Hour))
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		bucket.Close()
+		os.RemoveAll(dir)
+	})
+
+	Describe("func LiveDuration()", func() {
+		It("returns a handle to the current value", func() {
+			timeout := LiveDuration(bucket, "TIMEOUT", 5*time.Second)
+			defer timeout.Close()
+
+			Expect(timeout.Load()).To(Equal(10 * time.Second))
+		})
+
+		It("returns a handle to the default value if the key is undefined", func() {
+			timeout := LiveDuration(bucket, "UNDEFINED", 5*time.Second)
+			defer timeout.Close()
+
+			Expect(timeout.Load()).To(Equal(5 * time.Second))
+		})
+
+		It("updates the value when it changes", func() {
+			timeout := LiveDuration(bucket, "TIMEOUT", 5*time.Second)
+			defer timeout.Close()
+
+			reload("TIMEOUT=20s\n")
+			Expect(timeout.Load()).To(Equal(20 * time.Second))
+
+			reload("")
+			Expect(timeout.Load()).To(Equal(5 * time.Second))
+		})
+
+		It("panics if the current value is invalid", func() {
+			Expect(func() {
+				LiveDuration(bucket, "LIMIT", 5*time.Second)
+			}).To(PanicWith(InvalidValue{
+				Key:         "LIMIT",
+				Value:       "5",
+				Explanation: "expected a duration",
+				Source:      "line 2 of " + filepath.Join(dir, "app.env"),
+			}))
+		})
+	})
+
+	Describe("func NewLive()", func() {
+		It("returns a handle to the current value", func() {
+			limit := NewLive(bucket, "LIMIT", WithRange(1, 10))
+			defer limit.Close()
+
+			Expect(limit.Load()).To(Equal(5))
+
+			reload("LIMIT=7\n")
+			Expect(limit.Load()).To(Equal(7))
+		})
+
+		It("does not miss changes that occur while subscribing", func() {
+			b := racyBucket{
+				Map:   Map{"LIMIT": String("5")},
+				key:   "LIMIT",
+				value: "7",
+			}
+
+			limit := NewLive[int](b, "LIMIT")
+			defer limit.Close()
+
+			Expect(limit.Load()).To(Equal(7))
+		})
+
+		It("panics if the key is undefined and there is no default", func() {
+			Expect(func() {
+				NewLive[int](bucket, "UNDEFINED")
+			}).To(PanicWith(NotDefined{Key: "UNDEFINED"}))
+		})
+	})
+
+	Describe("func OnError()", func() {
+		It("retains the previous value and reports the error if the new value is invalid", func() {
+			limit := NewLive(bucket, "LIMIT", WithRange(1, 10))
+			defer limit.Close()
+
+			var errs []KeyError
+			limit.OnError(func(err KeyError) {
+				errs = append(errs, err)
+			})
+
+			reload("LIMIT=100\n")
+			Expect(limit.Load()).To(Equal(5))
+
+			reload("")
+			Expect(limit.Load()).To(Equal(5))
+
+			Expect(errs).To(Equal([]KeyError{
+				InvalidValue{
+					Key:         "LIMIT",
+					Value:       "100",
+					Explanation: "expected a value between 1 and 10 (inclusive)",
+					Source:      "line 1 of " + filepath.Join(dir, "app.env"),
+				},
+				NotDefined{Key: "LIMIT"},
+			}))
+
+			reload("LIMIT=3\n")
+			Expect(limit.Load()).To(Equal(3))
+		})
+	})
+
+	Describe("func Close()", func() {
+		It("stops tracking changes", func() {
+			limit := NewLive[int](bucket, "LIMIT")
+			limit.Close()
+
+			reload("LIMIT=7\n")
+			Expect(limit.Load()).To(Equal(5))
+		})
+	})
+})
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AsStringMap returns the map[string]string representation of the value
+// associated with k or panics if unable to do so.
+//
+// The value is a list of key/value pairs, such as "team=core,env=prod". Pairs
+// are separated by commas, unless a different separator is specified using
+// the ListSeparator() option. The key and value are separated by an equals
+// sign, unless a different separator is specified using the
+// MapKeyValueSeparator() option.
+//
+// Keys and values may be enclosed in double quotes in order to include either
+// separator or whitespace, as per AsStringSlice().
+func AsStringMap(b Bucket, k string, opts ...ListOption) map[string]string {
+	return asStringMap(b, k, accessor{"AsStringMap", false}, nil, false, opts)
+}
+
+// TryAsStringMap returns the map[string]string representation of the value
+// associated with k, or an error if unable to do so.
+//
+// See AsStringMap() for a description of the map syntax.
+func TryAsStringMap(b Bucket, k string, opts ...ListOption) (map[string]string, error) {
+	return try(func() map[string]string {
+		return asStringMap(b, k, accessor{"TryAsStringMap", false}, nil, false, opts)
+	})
+}
+
+// AsStringMapDefault returns the map[string]string representation of the
+// value associated with k, or the default value v if k is undefined.
+//
+// See AsStringMap() for a description of the map syntax.
+func AsStringMapDefault(b Bucket, k string, v map[string]string, opts ...ListOption) map[string]string {
+	return asStringMap(b, k, accessor{"AsStringMapDefault", false}, v, true, opts)
+}
+
+// TryAsStringMapDefault returns the map[string]string representation of the
+// value associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsStringMapDefault(b Bucket, k string, v map[string]string, opts ...ListOption) (map[string]string, error) {
+	return try(func() map[string]string {
+		return asStringMap(b, k, accessor{"TryAsStringMapDefault", false}, v, true, opts)
+	})
+}
+
+func asStringMap(
+	b Bucket,
+	k string,
+	a accessor,
+	d map[string]string,
+	hasDefault bool,
+	opts []ListOption,
+) map[string]string {
+	o := newListOptions(opts)
+
+	if hasDefault && (len(d) < o.min || len(d) > o.max) {
+		pairs := make([]string, 0, len(d))
+		for k, v := range d {
+			pairs = append(pairs, k+o.kvSep+v)
+		}
+		sort.Strings(pairs)
+
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: strings.Join(pairs, o.sep),
+			Explanation:  o.lengthExplanation(),
+		})
+	}
+
+	x := access(b, k, a)
+
+	if x.IsZero() {
+		if hasDefault {
+			return d
+		}
+
+		panic(notDefined(b, k))
+	}
+
+	s := mustAsString(qualify(b, k), x)
+
+	elements, err := splitList(s, o.sep, -1)
+	if err == nil {
+		err = o.checkLength(elements)
+	}
+	if err != nil {
+		panic(InvalidValue{
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: err.Error(),
+			Source:      x.Source(),
+		})
+	}
+
+	m := make(map[string]string, len(elements))
+
+	for i, e := range elements {
+		key, value, err := parseMapElement(e, o.kvSep)
+		if err == nil {
+			if _, ok := m[key]; ok {
+				err = fmt.Errorf(`duplicate key %#v`, key)
+			}
+		}
+
+		if err != nil {
+			panic(InvalidValue{
+				Key:         qualify(b, k),
+				Value:       s,
+				Explanation: fmt.Sprintf(`element %d (%#v) is invalid: %s`, i, strings.TrimSpace(e), err),
+				Source:      x.Source(),
+			})
+		}
+
+		m[key] = value
+	}
+
+	return m
+}
+
+// parseMapElement parses a single key/value pair produced by splitList().
+func parseMapElement(e, sep string) (string, string, error) {
+	parts, err := splitList(e, sep, 2)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf(`expected a key and value separated by %#v`, sep)
+	}
+
+	key, err := unquoteElement(parts[0])
+	if err != nil {
+		return "", "", err
+	}
+
+	if key == "" {
+		return "", "", errors.New(`expected a non-empty key`)
+	}
+
+	value, err := unquoteElement(parts[1])
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, value, nil
+}
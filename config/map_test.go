@@ -0,0 +1,69 @@
+package config_test
+
+import (
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func AsStringMap()", func() {
+	DescribeTable(
+		"it returns a map[string]string value",
+		func(value string, expect map[string]string, opts ...ListOption) {
+			b := Map{"<key>": String(value)}
+
+			v := AsStringMap(b, "<key>", opts...)
+			Expect(v).To(Equal(expect))
+		},
+		Entry("single pair", "team=core", map[string]string{"team": "core"}),
+		Entry("multiple pairs", "team=core, env=prod", map[string]string{"team": "core", "env": "prod"}),
+		Entry("empty value", "", map[string]string{}),
+		Entry("empty element value", "team=", map[string]string{"team": ""}),
+		Entry("separator in value", "expr=a=b", map[string]string{"expr": "a=b"}),
+		Entry("quoted keys and values", `"a,b"="c=d"`, map[string]string{"a,b": "c=d"}),
+		Entry("custom separators", "team:core;env:prod", map[string]string{"team": "core", "env": "prod"}, ListSeparator(";"), MapKeyValueSeparator(":")),
+	)
+
+	It("panics if the key is not defined", func() {
+		Expect(func() {
+			AsStringMap(Map{}, "<key>")
+		}).To(PanicWith(NotDefined{Key: "<key>"}))
+	})
+
+	DescribeTable(
+		"it panics if the value is invalid",
+		func(value, explanation string) {
+			b := Map{"<key>": String(value)}
+
+			Expect(func() {
+				AsStringMap(b, "<key>", ListLength(1, 2))
+			}).To(PanicWith(InvalidValue{
+				Key:         "<key>",
+				Value:       value,
+				Explanation: explanation,
+			}))
+		},
+		Entry("too many elements", "a=1,b=2,c=3", `expected between 1 and 2 elements (inclusive)`),
+		Entry("missing separator", "a=1,b", `element 1 ("b") is invalid: expected a key and value separated by "="`),
+		Entry("empty key", "=1", `element 0 ("=1") is invalid: expected a non-empty key`),
+		Entry("duplicate key", "a=1,a=2", `element 1 ("a=2") is invalid: duplicate key "a"`),
+	)
+})
+
+var _ = Describe("func AsStringMapDefault()", func() {
+	It("returns the default value if the key is not defined", func() {
+		v := AsStringMapDefault(Map{}, "<key>", map[string]string{"a": "1"})
+		Expect(v).To(Equal(map[string]string{"a": "1"}))
+	})
+
+	It("panics if the default value has an invalid length", func() {
+		Expect(func() {
+			AsStringMapDefault(Map{}, "<key>", map[string]string{"b": "2", "a": "1"}, ListLength(0, 1))
+		}).To(PanicWith(InvalidDefaultValue{
+			Key:          "<key>",
+			DefaultValue: "a=1,b=2",
+			Explanation:  `expected between 0 and 1 elements (inclusive)`,
+		}))
+	})
+})
@@ -0,0 +1,592 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"strconv"
+)
+
+// HostPort is a network address consisting of a host and a port.
+type HostPort struct {
+	// Host is the hostname or IP address. It may be empty if the address is a
+	// listen address, in which case it refers to all local addresses.
+	Host string
+
+	// Port is the port number.
+	Port uint16
+}
+
+// String returns the address in "host:port" form, as accepted by net.Dial()
+// and net.Listen().
+func (a HostPort) String() string {
+	return net.JoinHostPort(
+		a.Host,
+		strconv.FormatUint(uint64(a.Port), 10),
+	)
+}
+
+// AsNetworkPort returns the port number represented by the value associated
+// with k or panics if unable to do so.
+//
+// The value may be a port number between 1 and 65535, or an IANA service name
+// such as "https".
+func AsNetworkPort(b Bucket, k string) uint16 {
+	return asNetworkPort(b, k, accessor{"AsNetworkPort", false}, 1, math.MaxUint16)
+}
+
+// TryAsNetworkPort returns the port number represented by the value associated
+// with k, or an error if unable to do so.
+//
+// The value may be a port number between 1 and 65535, or an IANA service name
+// such as "https".
+func TryAsNetworkPort(b Bucket, k string) (uint16, error) {
+	return try(func() uint16 {
+		return asNetworkPort(b, k, accessor{"TryAsNetworkPort", false}, 1, math.MaxUint16)
+	})
+}
+
+// AsNetworkPortDefault returns the port number represented by the value
+// associated with k, or the default value v if k is undefined.
+//
+// The value may be a port number between 1 and 65535, or an IANA service name
+// such as "https".
+func AsNetworkPortDefault(b Bucket, k string, v uint16) uint16 {
+	return asNetworkPortDefault(b, k, accessor{"AsNetworkPortDefault", false}, v, 1, math.MaxUint16)
+}
+
+// TryAsNetworkPortDefault returns the port number represented by the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsNetworkPortDefault(b Bucket, k string, v uint16) (uint16, error) {
+	return try(func() uint16 {
+		return asNetworkPortDefault(b, k, accessor{"TryAsNetworkPortDefault", false}, v, 1, math.MaxUint16)
+	})
+}
+
+// AsNetworkPortBetween returns the port number represented by the value
+// associated with k or panics if unable to do so.
+//
+// The value may be a port number or an IANA service name such as "https". It
+// panics if the port is not between min and max (inclusive).
+func AsNetworkPortBetween(b Bucket, k string, min, max uint16) uint16 {
+	return asNetworkPort(b, k, accessor{"AsNetworkPortBetween", true}, min, max)
+}
+
+// TryAsNetworkPortBetween returns the port number represented by the value
+// associated with k, or an error if unable to do so.
+//
+// The value may be a port number or an IANA service name such as "https". It
+// returns an error if the port is not between min and max (inclusive).
+func TryAsNetworkPortBetween(b Bucket, k string, min, max uint16) (uint16, error) {
+	return try(func() uint16 {
+		return asNetworkPort(b, k, accessor{"TryAsNetworkPortBetween", true}, min, max)
+	})
+}
+
+// AsNetworkPortDefaultBetween returns the port number represented by the value
+// associated with k, or the default value v if k is undefined.
+//
+// The value may be a port number or an IANA service name such as "https". It
+// panics if the port is not between min and max (inclusive).
+func AsNetworkPortDefaultBetween(b Bucket, k string, v, min, max uint16) uint16 {
+	return asNetworkPortDefault(b, k, accessor{"AsNetworkPortDefaultBetween", true}, v, min, max)
+}
+
+// TryAsNetworkPortDefaultBetween returns the port number represented by the
+// value associated with k, or the default value v if k is undefined.
+//
+// The value may be a port number or an IANA service name such as "https". It
+// returns an error if the port is not between min and max (inclusive).
+func TryAsNetworkPortDefaultBetween(b Bucket, k string, v, min, max uint16) (uint16, error) {
+	return try(func() uint16 {
+		return asNetworkPortDefault(b, k, accessor{"TryAsNetworkPortDefaultBetween", true}, v, min, max)
+	})
+}
+
+// AsHostPort returns the host and port represented by the value associated
+// with k or panics if unable to do so.
+//
+// The value must be in "host:port" form, as accepted by net.SplitHostPort().
+// The port may be a port number or an IANA service name such as "https".
+func AsHostPort(b Bucket, k string) HostPort {
+	return asHostPort(b, k, accessor{"AsHostPort", false}, false, 1, math.MaxUint16)
+}
+
+// TryAsHostPort returns the host and port represented by the value associated
+// with k, or an error if unable to do so.
+//
+// See AsHostPort() for a description of the accepted syntax.
+func TryAsHostPort(b Bucket, k string) (HostPort, error) {
+	return try(func() HostPort {
+		return asHostPort(b, k, accessor{"TryAsHostPort", false}, false, 1, math.MaxUint16)
+	})
+}
+
+// AsHostPortDefault returns the host and port represented by the value
+// associated with k, or the default value v if k is undefined.
+//
+// See AsHostPort() for a description of the accepted syntax.
+func AsHostPortDefault(b Bucket, k, v string) HostPort {
+	return asHostPortDefault(b, k, accessor{"AsHostPortDefault", false}, v, false, 1, math.MaxUint16)
+}
+
+// TryAsHostPortDefault returns the host and port represented by the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsHostPortDefault(b Bucket, k, v string) (HostPort, error) {
+	return try(func() HostPort {
+		return asHostPortDefault(b, k, accessor{"TryAsHostPortDefault", false}, v, false, 1, math.MaxUint16)
+	})
+}
+
+// AsListenAddress returns the listen address represented by the value
+// associated with k or panics if unable to do so.
+//
+// The value must be in "host:port" form. Unlike AsHostPort(), the host may be
+// omitted (as in ":8080") to listen on all local addresses, and the port may
+// be zero to select an ephemeral port.
+func AsListenAddress(b Bucket, k string) HostPort {
+	return asHostPort(b, k, accessor{"AsListenAddress", false}, true, 0, math.MaxUint16)
+}
+
+// TryAsListenAddress returns the listen address represented by the value
+// associated with k, or an error if unable to do so.
+//
+// See AsListenAddress() for a description of the accepted syntax.
+func TryAsListenAddress(b Bucket, k string) (HostPort, error) {
+	return try(func() HostPort {
+		return asHostPort(b, k, accessor{"TryAsListenAddress", false}, true, 0, math.MaxUint16)
+	})
+}
+
+// AsListenAddressDefault returns the listen address represented by the value
+// associated with k, or the default value v if k is undefined.
+//
+// See AsListenAddress() for a description of the accepted syntax.
+func AsListenAddressDefault(b Bucket, k, v string) HostPort {
+	return asHostPortDefault(b, k, accessor{"AsListenAddressDefault", false}, v, true, 0, math.MaxUint16)
+}
+
+// TryAsListenAddressDefault returns the listen address represented by the
+// value associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsListenAddressDefault(b Bucket, k, v string) (HostPort, error) {
+	return try(func() HostPort {
+		return asHostPortDefault(b, k, accessor{"TryAsListenAddressDefault", false}, v, true, 0, math.MaxUint16)
+	})
+}
+
+// AsListenAddressBetween returns the listen address represented by the value
+// associated with k or panics if unable to do so.
+//
+// See AsListenAddress() for a description of the accepted syntax. It panics if
+// the port is not between min and max (inclusive).
+func AsListenAddressBetween(b Bucket, k string, min, max uint16) HostPort {
+	return asHostPort(b, k, accessor{"AsListenAddressBetween", true}, true, min, max)
+}
+
+// TryAsListenAddressBetween returns the listen address represented by the
+// value associated with k, or an error if unable to do so.
+//
+// See AsListenAddress() for a description of the accepted syntax. It returns
+// an error if the port is not between min and max (inclusive).
+func TryAsListenAddressBetween(b Bucket, k string, min, max uint16) (HostPort, error) {
+	return try(func() HostPort {
+		return asHostPort(b, k, accessor{"TryAsListenAddressBetween", true}, true, min, max)
+	})
+}
+
+// AsListenAddressDefaultBetween returns the listen address represented by the
+// value associated with k, or the default value v if k is undefined.
+//
+// See AsListenAddress() for a description of the accepted syntax. It panics if
+// the port is not between min and max (inclusive).
+func AsListenAddressDefaultBetween(b Bucket, k, v string, min, max uint16) HostPort {
+	return asHostPortDefault(b, k, accessor{"AsListenAddressDefaultBetween", true}, v, true, min, max)
+}
+
+// TryAsListenAddressDefaultBetween returns the listen address represented by
+// the value associated with k, or the default value v if k is undefined.
+//
+// See AsListenAddress() for a description of the accepted syntax. It returns
+// an error if the port is not between min and max (inclusive).
+func TryAsListenAddressDefaultBetween(b Bucket, k, v string, min, max uint16) (HostPort, error) {
+	return try(func() HostPort {
+		return asHostPortDefault(b, k, accessor{"TryAsListenAddressDefaultBetween", true}, v, true, min, max)
+	})
+}
+
+// AsIPAddr returns the netip.Addr representation of the value associated with
+// k or panics if unable to do so.
+func AsIPAddr(b Bucket, k string) netip.Addr {
+	return asIPAddr(b, k, accessor{"AsIPAddr", false})
+}
+
+// TryAsIPAddr returns the netip.Addr representation of the value associated
+// with k, or an error if unable to do so.
+func TryAsIPAddr(b Bucket, k string) (netip.Addr, error) {
+	return try(func() netip.Addr {
+		return asIPAddr(b, k, accessor{"TryAsIPAddr", false})
+	})
+}
+
+// AsIPAddrDefault returns the netip.Addr representation of the value
+// associated with k, or the default value v if k is undefined.
+func AsIPAddrDefault(b Bucket, k string, v netip.Addr) netip.Addr {
+	return asIPAddrDefault(b, k, accessor{"AsIPAddrDefault", false}, v)
+}
+
+// TryAsIPAddrDefault returns the netip.Addr representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsIPAddrDefault(b Bucket, k string, v netip.Addr) (netip.Addr, error) {
+	return try(func() netip.Addr {
+		return asIPAddrDefault(b, k, accessor{"TryAsIPAddrDefault", false}, v)
+	})
+}
+
+// AsIPPrefix returns the netip.Prefix representation of the value associated
+// with k or panics if unable to do so.
+//
+// The value must be in CIDR notation, such as "192.168.0.0/16".
+func AsIPPrefix(b Bucket, k string) netip.Prefix {
+	return asIPPrefix(b, k, accessor{"AsIPPrefix", false})
+}
+
+// TryAsIPPrefix returns the netip.Prefix representation of the value
+// associated with k, or an error if unable to do so.
+//
+// The value must be in CIDR notation, such as "192.168.0.0/16".
+func TryAsIPPrefix(b Bucket, k string) (netip.Prefix, error) {
+	return try(func() netip.Prefix {
+		return asIPPrefix(b, k, accessor{"TryAsIPPrefix", false})
+	})
+}
+
+// AsIPPrefixDefault returns the netip.Prefix representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// The value must be in CIDR notation, such as "192.168.0.0/16".
+func AsIPPrefixDefault(b Bucket, k string, v netip.Prefix) netip.Prefix {
+	return asIPPrefixDefault(b, k, accessor{"AsIPPrefixDefault", false}, v)
+}
+
+// TryAsIPPrefixDefault returns the netip.Prefix representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsIPPrefixDefault(b Bucket, k string, v netip.Prefix) (netip.Prefix, error) {
+	return try(func() netip.Prefix {
+		return asIPPrefixDefault(b, k, accessor{"TryAsIPPrefixDefault", false}, v)
+	})
+}
+
+// parsePort parses the port number or IANA service name in s.
+func parsePort(s string, min, max uint16) (uint16, string) {
+	expl := fmt.Sprintf(
+		`expected a port number between %d and %d (inclusive), or an IANA service name`,
+		min,
+		max,
+	)
+
+	if s == "" {
+		return 0, expl
+	}
+
+	n, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && numErr.Err == strconv.ErrRange {
+			return 0, expl
+		}
+
+		p, err := net.LookupPort("tcp", s)
+		if err != nil {
+			return 0, expl
+		}
+
+		n = uint64(p)
+	}
+
+	if uint64(min) > n || n > uint64(max) {
+		return 0, expl
+	}
+
+	return uint16(n), ""
+}
+
+// parseHostPort parses the "host:port" address in s.
+//
+// If the address is valid the explanation is empty.
+func parseHostPort(s string, allowEmptyHost bool, min, max uint16) (HostPort, string) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return HostPort{}, fmt.Sprintf(
+			`expected a host and port (%s)`,
+			err.(*net.AddrError).Err,
+		)
+	}
+
+	if host == "" && !allowEmptyHost {
+		return HostPort{}, `expected a host and port (missing host in address)`
+	}
+
+	p, expl := parsePort(port, min, max)
+	if expl != "" {
+		return HostPort{}, expl
+	}
+
+	return HostPort{host, p}, ""
+}
+
+func tryAsNetworkPort(
+	b Bucket,
+	k string,
+	a accessor,
+	min, max uint16,
+) (uint16, bool) {
+	annotateRange(b, k, a, min, max)
+
+	x := access(b, k, a)
+
+	if x.IsZero() {
+		return 0, false
+	}
+
+	s := mustAsString(qualify(b, k), x)
+
+	v, expl := parsePort(s, min, max)
+	if expl != "" {
+		panic(InvalidValue{
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: expl,
+			Source:      x.Source(),
+		})
+	}
+
+	return v, true
+}
+
+func asNetworkPort(
+	b Bucket,
+	k string,
+	a accessor,
+	min, max uint16,
+) uint16 {
+	if v, ok := tryAsNetworkPort(b, k, a, min, max); ok {
+		return v
+	}
+
+	panic(notDefined(b, k))
+}
+
+func asNetworkPortDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	d, min, max uint16,
+) uint16 {
+	annotateDefault(b, k, a, d)
+
+	if min > d || d > max {
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: fmt.Sprintf(`%d`, d),
+			Explanation: fmt.Sprintf(
+				`expected a port number between %d and %d (inclusive)`,
+				min,
+				max,
+			),
+		})
+	}
+
+	if v, ok := tryAsNetworkPort(b, k, a, min, max); ok {
+		return v
+	}
+
+	return d
+}
+
+func tryAsHostPort(
+	b Bucket,
+	k string,
+	a accessor,
+	allowEmptyHost bool,
+	min, max uint16,
+) (HostPort, bool) {
+	annotateRange(b, k, a, min, max)
+
+	x := access(b, k, a)
+
+	if x.IsZero() {
+		return HostPort{}, false
+	}
+
+	s := mustAsString(qualify(b, k), x)
+
+	v, expl := parseHostPort(s, allowEmptyHost, min, max)
+	if expl != "" {
+		panic(InvalidValue{
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: expl,
+			Source:      x.Source(),
+		})
+	}
+
+	return v, true
+}
+
+func asHostPort(
+	b Bucket,
+	k string,
+	a accessor,
+	allowEmptyHost bool,
+	min, max uint16,
+) HostPort {
+	if v, ok := tryAsHostPort(b, k, a, allowEmptyHost, min, max); ok {
+		return v
+	}
+
+	panic(notDefined(b, k))
+}
+
+func asHostPortDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	v string,
+	allowEmptyHost bool,
+	min, max uint16,
+) HostPort {
+	annotateDefault(b, k, a, v)
+
+	d, expl := parseHostPort(v, allowEmptyHost, min, max)
+	if expl != "" {
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: v,
+			Explanation:  expl,
+		})
+	}
+
+	if v, ok := tryAsHostPort(b, k, a, allowEmptyHost, min, max); ok {
+		return v
+	}
+
+	return d
+}
+
+func tryAsIPAddr(
+	b Bucket,
+	k string,
+	a accessor,
+) (netip.Addr, bool) {
+	x := access(b, k, a)
+
+	if x.IsZero() {
+		return netip.Addr{}, false
+	}
+
+	s := mustAsString(qualify(b, k), x)
+
+	v, err := netip.ParseAddr(s)
+	if err != nil {
+		panic(InvalidValue{
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: `expected an IPv4 or IPv6 address`,
+			Source:      x.Source(),
+		})
+	}
+
+	return v, true
+}
+
+func tryAsIPPrefix(
+	b Bucket,
+	k string,
+	a accessor,
+) (netip.Prefix, bool) {
+	x := access(b, k, a)
+
+	if x.IsZero() {
+		return netip.Prefix{}, false
+	}
+
+	s := mustAsString(qualify(b, k), x)
+
+	v, err := netip.ParsePrefix(s)
+	if err != nil {
+		panic(InvalidValue{
+			Key:         qualify(b, k),
+			Value:       s,
+			Explanation: `expected an IP address prefix in CIDR notation`,
+			Source:      x.Source(),
+		})
+	}
+
+	return v, true
+}
+
+func asIPAddr(
+	b Bucket,
+	k string,
+	a accessor,
+) netip.Addr {
+	if v, ok := tryAsIPAddr(b, k, a); ok {
+		return v
+	}
+
+	panic(notDefined(b, k))
+}
+
+func asIPAddrDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	d netip.Addr,
+) netip.Addr {
+	annotateDefault(b, k, a, d)
+
+	if v, ok := tryAsIPAddr(b, k, a); ok {
+		return v
+	}
+
+	return d
+}
+
+func asIPPrefix(
+	b Bucket,
+	k string,
+	a accessor,
+) netip.Prefix {
+	if v, ok := tryAsIPPrefix(b, k, a); ok {
+		return v
+	}
+
+	panic(notDefined(b, k))
+}
+
+func asIPPrefixDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	d netip.Prefix,
+) netip.Prefix {
+	annotateDefault(b, k, a, d)
+
+	if v, ok := tryAsIPPrefix(b, k, a); ok {
+		return v
+	}
+
+	return d
+}
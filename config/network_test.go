@@ -0,0 +1,224 @@
+package config_test
+
+import (
+	"net/netip"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("type HostPort", func() {
+	Describe("func String()", func() {
+		It("returns the address in host:port form", func() {
+			Expect(HostPort{"example.org", 443}.String()).To(Equal("example.org:443"))
+			Expect(HostPort{"::1", 443}.String()).To(Equal("[::1]:443"))
+			Expect(HostPort{"", 8080}.String()).To(Equal(":8080"))
+		})
+	})
+})
+
+var _ = Describe("func AsNetworkPort()", func() {
+	DescribeTable(
+		"it returns the port number",
+		func(value string, expect int) {
+			b := Map{"<key>": String(value)}
+
+			v := AsNetworkPort(b, "<key>")
+			Expect(v).To(BeNumerically("==", expect))
+		},
+		Entry("numeric", "8080", 8080),
+		Entry("service name", "https", 443),
+	)
+
+	It("panics if the key is not defined", func() {
+		Expect(func() {
+			AsNetworkPort(Map{}, "<key>")
+		}).To(PanicWith(NotDefined{Key: "<key>"}))
+	})
+
+	DescribeTable(
+		"it panics if the value is invalid",
+		func(value string) {
+			b := Map{"<key>": String(value)}
+
+			Expect(func() {
+				AsNetworkPort(b, "<key>")
+			}).To(PanicWith(InvalidValue{
+				Key:         "<key>",
+				Value:       value,
+				Explanation: `expected a port number between 1 and 65535 (inclusive), or an IANA service name`,
+			}))
+		},
+		Entry("zero", "0"),
+		Entry("too large", "65536"),
+		Entry("negative", "-1"),
+		Entry("unknown service name", "<unknown>"),
+	)
+})
+
+var _ = Describe("func AsNetworkPortDefaultBetween()", func() {
+	It("returns the default value if the key is not defined", func() {
+		v := AsNetworkPortDefaultBetween(Map{}, "<key>", 8080, 1024, 9000)
+		Expect(v).To(BeNumerically("==", 8080))
+	})
+
+	It("panics if the value is out of range", func() {
+		b := Map{"<key>": String("https")}
+
+		Expect(func() {
+			AsNetworkPortDefaultBetween(b, "<key>", 8080, 1024, 9000)
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "https",
+			Explanation: `expected a port number between 1024 and 9000 (inclusive), or an IANA service name`,
+		}))
+	})
+
+	It("panics if the default value is out of range", func() {
+		Expect(func() {
+			AsNetworkPortDefaultBetween(Map{}, "<key>", 80, 1024, 9000)
+		}).To(PanicWith(InvalidDefaultValue{
+			Key:          "<key>",
+			DefaultValue: "80",
+			Explanation:  `expected a port number between 1024 and 9000 (inclusive)`,
+		}))
+	})
+})
+
+var _ = Describe("func AsHostPort()", func() {
+	DescribeTable(
+		"it returns the host and port",
+		func(value string, expect HostPort) {
+			b := Map{"<key>": String(value)}
+
+			v := AsHostPort(b, "<key>")
+			Expect(v).To(Equal(expect))
+		},
+		Entry("hostname", "example.org:9092", HostPort{"example.org", 9092}),
+		Entry("IPv6 address", "[::1]:9092", HostPort{"::1", 9092}),
+		Entry("service name", "example.org:https", HostPort{"example.org", 443}),
+	)
+
+	DescribeTable(
+		"it panics if the value is invalid",
+		func(value, explanation string) {
+			b := Map{"<key>": String(value)}
+
+			Expect(func() {
+				AsHostPort(b, "<key>")
+			}).To(PanicWith(InvalidValue{
+				Key:         "<key>",
+				Value:       value,
+				Explanation: explanation,
+			}))
+		},
+		Entry("missing port", "example.org", `expected a host and port (missing port in address)`),
+		Entry("missing host", ":9092", `expected a host and port (missing host in address)`),
+		Entry("invalid port", "example.org:0", `expected a port number between 1 and 65535 (inclusive), or an IANA service name`),
+	)
+})
+
+var _ = Describe("func AsHostPortDefault()", func() {
+	It("returns the default value if the key is not defined", func() {
+		v := AsHostPortDefault(Map{}, "<key>", "localhost:9092")
+		Expect(v).To(Equal(HostPort{"localhost", 9092}))
+	})
+
+	It("panics if the default value is invalid", func() {
+		Expect(func() {
+			AsHostPortDefault(Map{}, "<key>", "localhost")
+		}).To(PanicWith(InvalidDefaultValue{
+			Key:          "<key>",
+			DefaultValue: "localhost",
+			Explanation:  `expected a host and port (missing port in address)`,
+		}))
+	})
+})
+
+var _ = Describe("func AsListenAddress()", func() {
+	DescribeTable(
+		"it returns the listen address",
+		func(value string, expect HostPort) {
+			b := Map{"<key>": String(value)}
+
+			v := AsListenAddress(b, "<key>")
+			Expect(v).To(Equal(expect))
+		},
+		Entry("all interfaces", ":8080", HostPort{"", 8080}),
+		Entry("specific interface", "127.0.0.1:8080", HostPort{"127.0.0.1", 8080}),
+		Entry("ephemeral port", ":0", HostPort{"", 0}),
+	)
+})
+
+var _ = Describe("func AsListenAddressDefaultBetween()", func() {
+	It("returns the default value if the key is not defined", func() {
+		v := AsListenAddressDefaultBetween(Map{}, "<key>", ":8080", 1024, 9000)
+		Expect(v).To(Equal(HostPort{"", 8080}))
+	})
+
+	It("panics if the port is out of range", func() {
+		b := Map{"<key>": String(":80")}
+
+		Expect(func() {
+			AsListenAddressDefaultBetween(b, "<key>", ":8080", 1024, 9000)
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       ":80",
+			Explanation: `expected a port number between 1024 and 9000 (inclusive), or an IANA service name`,
+		}))
+	})
+})
+
+var _ = Describe("func AsIPAddr()", func() {
+	It("returns the IP address", func() {
+		b := Map{"<key>": String("fe80::1")}
+
+		v := AsIPAddr(b, "<key>")
+		Expect(v).To(Equal(netip.MustParseAddr("fe80::1")))
+	})
+
+	It("panics if the value is invalid", func() {
+		b := Map{"<key>": String("256.0.0.1")}
+
+		Expect(func() {
+			AsIPAddr(b, "<key>")
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "256.0.0.1",
+			Explanation: `expected an IPv4 or IPv6 address`,
+		}))
+	})
+
+	It("returns the default value if the key is not defined", func() {
+		v := AsIPAddrDefault(Map{}, "<key>", netip.MustParseAddr("127.0.0.1"))
+		Expect(v).To(Equal(netip.MustParseAddr("127.0.0.1")))
+	})
+})
+
+var _ = Describe("func AsIPPrefix()", func() {
+	It("returns the IP prefix", func() {
+		b := Map{"<key>": String("10.0.0.0/8")}
+
+		v := AsIPPrefix(b, "<key>")
+		Expect(v).To(Equal(netip.MustParsePrefix("10.0.0.0/8")))
+	})
+
+	It("panics if the value is invalid", func() {
+		b := Map{"<key>": String("10.0.0.0")}
+
+		Expect(func() {
+			AsIPPrefix(b, "<key>")
+		}).To(PanicWith(InvalidValue{
+			Key:         "<key>",
+			Value:       "10.0.0.0",
+			Explanation: `expected an IP address prefix in CIDR notation`,
+		}))
+	})
+
+	It("returns an error from the Try variant if the key is not defined", func() {
+		_, err := TryAsIPPrefix(Map{}, "<key>")
+		Expect(err).To(Equal(NotDefined{Key: "<key>"}))
+	})
+})
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ParseFunc is a function that parses the string representation of a
+// configuration value of type T.
+//
+// If s is not a valid representation of T, it returns an error that explains
+// the expected format, such as "expected a duration".
+type ParseFunc[T any] func(s string) (T, error)
+
+// RegisterParser registers fn as the function used by Get() and TryGet() to
+// parse values of type T.
+//
+// It replaces any existing parser for T, including the built-in parsers.
+func RegisterParser[T any](fn ParseFunc[T]) {
+	parsers.Store(typeOf[T](), fn)
+}
+
+// parsers is a map of reflect.Type to the ParseFunc for that type.
+var parsers sync.Map
+
+// parserFor returns the parser used to parse the value associated with k as
+// type T.
+//
+// It panics with an UnsupportedType error if there is no parser registered for
+// T.
+func parserFor[T any](b Bucket, k string) ParseFunc[T] {
+	t := typeOf[T]()
+
+	if fn, ok := parsers.Load(t); ok {
+		return fn.(ParseFunc[T])
+	}
+
+	panic(UnsupportedType{
+		Key:  qualify(b, k),
+		Type: t.String(),
+	})
+}
+
+// hasParser returns true if there is a parser registered for values of type t.
+func hasParser(t reflect.Type) bool {
+	_, ok := parsers.Load(t)
+	return ok
+}
+
+// typeOf returns the reflect.Type for T.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func init() {
+	RegisterParser(func(s string) (string, error) {
+		return s, nil
+	})
+
+	RegisterParser(func(s string) ([]byte, error) {
+		return []byte(s), nil
+	})
+
+	RegisterParser(func(s string) (bool, error) {
+		if v, ok := parseBool(s); ok {
+			return v, nil
+		}
+
+		return false, errors.New(boolExplanation)
+	})
+
+	RegisterParser(parseSigned[int])
+	RegisterParser(parseSigned[int8])
+	RegisterParser(parseSigned[int16])
+	RegisterParser(parseSigned[int32])
+	RegisterParser(parseSigned[int64])
+
+	RegisterParser(parseUnsigned[uint])
+	RegisterParser(parseUnsigned[uint8])
+	RegisterParser(parseUnsigned[uint16])
+	RegisterParser(parseUnsigned[uint32])
+	RegisterParser(parseUnsigned[uint64])
+
+	RegisterParser(parseFloat[float32])
+	RegisterParser(parseFloat[float64])
+
+	RegisterParser(func(s string) (time.Duration, error) {
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, errors.New(`expected a duration`)
+		}
+
+		return v, nil
+	})
+
+	RegisterParser(func(s string) (*url.URL, error) {
+		v, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf(
+				`expected a URL (%s)`,
+				err.(*url.Error).Unwrap(),
+			)
+		}
+
+		return v, nil
+	})
+}
+
+// parseSigned is a ParseFunc for the built-in signed integer types.
+func parseSigned[T int | int8 | int16 | int32 | int64](s string) (T, error) {
+	bitSize := typeOf[T]().Bits()
+
+	v, err := strconv.ParseInt(s, 10, bitSize)
+	if err != nil {
+		min := int64(-1) << (bitSize - 1)
+
+		return 0, fmt.Errorf(
+			`expected an integer between %d and %d (inclusive)`,
+			min,
+			-(min + 1),
+		)
+	}
+
+	return T(v), nil
+}
+
+// parseUnsigned is a ParseFunc for the built-in unsigned integer types.
+func parseUnsigned[T uint | uint8 | uint16 | uint32 | uint64](s string) (T, error) {
+	bitSize := typeOf[T]().Bits()
+
+	v, err := strconv.ParseUint(s, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf(
+			`expected an integer between 0 and %d (inclusive)`,
+			uint64(math.MaxUint64)>>(64-bitSize),
+		)
+	}
+
+	return T(v), nil
+}
+
+// parseFloat is a ParseFunc for the built-in floating-point types.
+func parseFloat[T float32 | float64](s string) (T, error) {
+	bitSize := typeOf[T]().Bits()
+
+	v, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf(`expected a %d-bit floating-point number`, bitSize)
+	}
+
+	return T(v), nil
+}
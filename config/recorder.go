@@ -0,0 +1,264 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Record returns a bucket that records each access to the keys in b.
+//
+// It is intended to be used to discover the keys that a program uses, for
+// example by running a test suite with a recording bucket.
+func Record(b Bucket) *Recorder {
+	return &Recorder{
+		parent:   b,
+		accesses: map[accessKey]*Access{},
+	}
+}
+
+// Recorder is an implementation of Bucket that records each access to the
+// keys in another bucket.
+type Recorder struct {
+	parent Bucket
+
+	m        sync.Mutex
+	accesses map[accessKey]*Access
+}
+
+// Access describes the way in which a key was accessed.
+type Access struct {
+	// Key is the name of the key.
+	Key string `json:"key"`
+
+	// Accessor is the name of the function that accessed the key, such as
+	// "AsIntBetween". It is empty if Bucket.Get() or GetDefault() was called
+	// directly. Fields populated by Bind() are recorded under the name of the
+	// equivalent function, such as "AsInt32DefaultBetween".
+	Accessor string `json:"accessor,omitempty"`
+
+	// Default is a string representation of the default value, if any.
+	Default string `json:"default,omitempty"`
+
+	// HasDefault indicates whether a default value was supplied.
+	HasDefault bool `json:"hasDefault"`
+
+	// Min and Max are string representations of the range of permitted values,
+	// if any.
+	Min string `json:"min,omitempty"`
+	Max string `json:"max,omitempty"`
+
+	// Defined indicates whether the key was defined when it was most recently
+	// accessed.
+	Defined bool `json:"defined"`
+}
+
+// accessKey uniquely identifies the accesses recorded by a Recorder.
+type accessKey struct {
+	Key      string
+	Accessor string
+}
+
+// Get returns the value associated with the given key.
+//
+// If they key is not defined, it returns a zero-value.
+func (r *Recorder) Get(k string) Value {
+	return r.access(k, accessor{})
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// If the key is not defined, it returns a value with the content of v.
+func (r *Recorder) GetDefault(k string, v string) Value {
+	x := r.parent.Get(k)
+
+	r.annotate(k, accessor{}, func(a *Access) {
+		a.Defined = !x.IsZero()
+		a.HasDefault = true
+		a.Default = v
+	})
+
+	if x.IsZero() {
+		return String(v)
+	}
+
+	return x
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// Keys visited by Each are not recorded.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+func (r *Recorder) Each(fn EachFunc) bool {
+	return r.parent.Each(fn)
+}
+
+// Accesses returns the recorded accesses, sorted by key.
+//
+// A key that is accessed more than once by the same accessor is reported only
+// once.
+func (r *Recorder) Accesses() []Access {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	accesses := make([]Access, 0, len(r.accesses))
+	for _, a := range r.accesses {
+		accesses = append(accesses, *a)
+	}
+
+	sort.Slice(accesses, func(i, j int) bool {
+		if accesses[i].Key != accesses[j].Key {
+			return accesses[i].Key < accesses[j].Key
+		}
+
+		return accesses[i].Accessor < accesses[j].Accessor
+	})
+
+	return accesses
+}
+
+// JSON returns a JSON representation of the recorded accesses.
+func (r *Recorder) JSON() ([]byte, error) {
+	return json.MarshalIndent(r.Accesses(), "", "  ")
+}
+
+// Markdown returns a Markdown table that documents the recorded keys, in the
+// same format as Spec.Markdown().
+func (r *Recorder) Markdown() string {
+	var keys []KeySpec
+
+	for _, a := range r.Accesses() {
+		accessor := "Get"
+		if a.Accessor != "" {
+			accessor = a.Accessor
+		}
+		accessor += "()"
+
+		n := len(keys)
+		if n == 0 || keys[n-1].Name != a.Key {
+			keys = append(keys, KeySpec{
+				Name:     a.Key,
+				Type:     accessor,
+				Required: true,
+			})
+			n++
+		} else {
+			keys[n-1].Type += ", " + accessor
+		}
+
+		k := &keys[n-1]
+
+		if a.HasDefault && !k.HasDefault {
+			k.HasDefault = true
+			k.Default = a.Default
+			k.Required = false
+		}
+
+		if a.Min != "" || a.Max != "" {
+			k.Min, k.Max = a.Min, a.Max
+		}
+	}
+
+	return renderMarkdown(keys)
+}
+
+// access returns the value associated with k, recording that it was accessed
+// by the accessor function a.
+func (r *Recorder) access(k string, a accessor) Value {
+	x := r.parent.Get(k)
+
+	r.annotate(k, a, func(acc *Access) {
+		acc.Defined = !x.IsZero()
+	})
+
+	return x
+}
+
+// annotate updates the record of k being accessed by a using fn.
+func (r *Recorder) annotate(k string, a accessor, fn func(*Access)) {
+	key := accessKey{k, a.name}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	acc, ok := r.accesses[key]
+	if !ok {
+		acc = &Access{
+			Key:      key.Key,
+			Accessor: key.Accessor,
+		}
+		r.accesses[key] = acc
+	}
+
+	fn(acc)
+}
+
+// qualifiedKey returns the key used to identify k in the parent bucket.
+func (r *Recorder) qualifiedKey(k string) string {
+	return qualify(r.parent, k)
+}
+
+// accessor describes the function used to access a key.
+type accessor struct {
+	// name is the name of the function, such as "AsIntBetween". It is empty
+	// if Bucket.Get() or GetDefault() is called directly.
+	name string
+
+	// ranged indicates whether the caller supplied the range of permitted
+	// values, as opposed to it being the range of the underlying type.
+	ranged bool
+}
+
+// annotator is an interface for buckets that record information about how
+// keys are accessed.
+type annotator interface {
+	access(k string, a accessor) Value
+	annotate(k string, a accessor, fn func(*Access))
+}
+
+// access returns the value associated with k, recording that it was accessed
+// by a if b records accesses.
+func access(b Bucket, k string, a accessor) Value {
+	if r, ok := b.(annotator); ok {
+		return r.access(k, a)
+	}
+
+	return b.Get(k)
+}
+
+// annotate updates the record of k being accessed by a, if b records
+// accesses.
+func annotate(b Bucket, k string, a accessor, fn func(*Access)) {
+	if r, ok := b.(annotator); ok {
+		r.annotate(k, a, fn)
+	}
+}
+
+// annotateDefault records the default value for k, if b records accesses.
+func annotateDefault(b Bucket, k string, a accessor, v interface{}) {
+	annotate(b, k, a, func(acc *Access) {
+		acc.HasDefault = true
+		acc.Default = fmt.Sprint(v)
+	})
+}
+
+// annotateRange records the range of permitted values for k, if b records
+// accesses.
+//
+// The range is only recorded if a.ranged is true, otherwise it is the range of
+// the underlying type.
+func annotateRange(b Bucket, k string, a accessor, min, max interface{}) {
+	if !a.ranged {
+		return
+	}
+
+	annotate(b, k, a, func(acc *Access) {
+		acc.Min = fmt.Sprint(min)
+		acc.Max = fmt.Sprint(max)
+	})
+}
@@ -0,0 +1,261 @@
+package config_test
+
+import (
+	"net/netip"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Record()", func() {
+	var recorder *Recorder
+
+	BeforeEach(func() {
+		recorder = Record(
+			Map{
+				"WORKERS":  String("8"),
+				"ENDPOINT": String("https://example.org"),
+				"DB_HOST":  String("<host>"),
+			},
+		)
+	})
+
+	It("returns values from the underlying bucket", func() {
+		Expect(recorder.Get("WORKERS")).To(Equal(String("8")))
+		Expect(recorder.GetDefault("UNDEFINED", "<default>")).To(Equal(String("<default>")))
+
+		var keys []string
+		recorder.Each(func(k string, v Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		Expect(keys).To(ConsistOf("WORKERS", "ENDPOINT", "DB_HOST"))
+	})
+
+	Describe("func Accesses()", func() {
+		It("records direct calls to Get() and GetDefault()", func() {
+			recorder.Get("WORKERS")
+			recorder.GetDefault("UNDEFINED", "<default>")
+
+			Expect(recorder.Accesses()).To(Equal([]Access{
+				{
+					Key:        "UNDEFINED",
+					Default:    "<default>",
+					HasDefault: true,
+				},
+				{
+					Key:     "WORKERS",
+					Defined: true,
+				},
+			}))
+		})
+
+		It("records the accessor, default value and range", func() {
+			AsIntDefaultBetween(recorder, "WORKERS", 4, 1, 100)
+			AsDurationDefault(recorder, "TIMEOUT", 30*time.Second)
+			AsURL(recorder, "ENDPOINT")
+			TryAsString(recorder, "UNDEFINED")
+			Get(recorder, "LIMIT", WithDefault(10), WithRange(1, 20))
+
+			Expect(recorder.Accesses()).To(Equal([]Access{
+				{
+					Key:      "ENDPOINT",
+					Accessor: "AsURL",
+					Defined:  true,
+				},
+				{
+					Key:        "LIMIT",
+					Accessor:   "Get",
+					Default:    "10",
+					HasDefault: true,
+					Min:        "1",
+					Max:        "20",
+				},
+				{
+					Key:        "TIMEOUT",
+					Accessor:   "AsDurationDefault",
+					Default:    "30s",
+					HasDefault: true,
+				},
+				{
+					Key:      "UNDEFINED",
+					Accessor: "TryAsString",
+				},
+				{
+					Key:        "WORKERS",
+					Accessor:   "AsIntDefaultBetween",
+					Default:    "4",
+					HasDefault: true,
+					Min:        "1",
+					Max:        "100",
+					Defined:    true,
+				},
+			}))
+		})
+
+		It("records the default value and port range of network addresses", func() {
+			AsListenAddressDefaultBetween(recorder, "LISTEN_ADDR", ":8080", 1024, 49151)
+			AsIPAddrDefault(recorder, "BIND_IP", netip.MustParseAddr("127.0.0.1"))
+			AsIPPrefixDefault(recorder, "TRUSTED_NET", netip.MustParsePrefix("10.0.0.0/8"))
+
+			Expect(recorder.Accesses()).To(Equal([]Access{
+				{
+					Key:        "BIND_IP",
+					Accessor:   "AsIPAddrDefault",
+					Default:    "127.0.0.1",
+					HasDefault: true,
+				},
+				{
+					Key:        "LISTEN_ADDR",
+					Accessor:   "AsListenAddressDefaultBetween",
+					Default:    ":8080",
+					HasDefault: true,
+					Min:        "1024",
+					Max:        "49151",
+				},
+				{
+					Key:        "TRUSTED_NET",
+					Accessor:   "AsIPPrefixDefault",
+					Default:    "10.0.0.0/8",
+					HasDefault: true,
+				},
+			}))
+		})
+
+		It("records the equivalent accessor, default value and range of bound fields", func() {
+			var cfg struct {
+				Workers int32         `config:"WORKERS" min:"1" max:"100"`
+				Timeout time.Duration `config:"TIMEOUT" default:"30s"`
+				Limit   uint          `config:"LIMIT" default:"10" max:"20"`
+			}
+
+			err := Bind(recorder, &cfg)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(recorder.Accesses()).To(Equal([]Access{
+				{
+					Key:        "LIMIT",
+					Accessor:   "AsUintDefaultBetween",
+					Default:    "10",
+					HasDefault: true,
+					Min:        "0",
+					Max:        "20",
+				},
+				{
+					Key:        "TIMEOUT",
+					Accessor:   "AsDurationDefault",
+					Default:    "30s",
+					HasDefault: true,
+				},
+				{
+					Key:      "WORKERS",
+					Accessor: "AsInt32Between",
+					Min:      "1",
+					Max:      "100",
+					Defined:  true,
+				},
+			}))
+		})
+
+		It("records the accessor used by each key of a composite value", func() {
+			_, err := AsTLSConfig(recorder, "TLS_")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var accessors []string
+			for _, a := range recorder.Accesses() {
+				accessors = append(accessors, a.Key+" "+a.Accessor)
+			}
+
+			Expect(accessors).To(Equal([]string{
+				"TLS_CA TryAsBytes",
+				"TLS_CERT TryAsBytes",
+				"TLS_CLIENT_AUTH TryAsEnumDefault",
+				"TLS_KEY TryAsBytes",
+				"TLS_MIN_VERSION TryAsEnumDefault",
+				"TLS_SERVER_NAME TryAsStringDefault",
+			}))
+		})
+
+		It("records the name of methods and generic functions", func() {
+			Enum{Values: []string{"8"}}.As(recorder, "WORKERS")
+			TryGet[int](recorder, "WORKERS")
+
+			Expect(recorder.Accesses()).To(Equal([]Access{
+				{
+					Key:      "WORKERS",
+					Accessor: "Enum.As",
+					Defined:  true,
+				},
+				{
+					Key:      "WORKERS",
+					Accessor: "TryGet",
+					Defined:  true,
+				},
+			}))
+		})
+
+		It("records the fully-qualified key when accessed via Sub()", func() {
+			AsString(Sub(recorder, "DB_"), "HOST")
+
+			Expect(recorder.Accesses()).To(Equal([]Access{
+				{
+					Key:      "DB_HOST",
+					Accessor: "AsString",
+					Defined:  true,
+				},
+			}))
+		})
+
+		It("reports the fully-qualified key in errors when recording a Sub() bucket", func() {
+			r := Record(Sub(Map{}, "DB_"))
+
+			Expect(func() {
+				AsString(r, "PORT")
+			}).To(PanicWith(NotDefined{Key: "DB_PORT"}))
+		})
+	})
+
+	Describe("func JSON()", func() {
+		It("returns a JSON representation of the accesses", func() {
+			AsIntBetween(recorder, "WORKERS", 1, 100)
+			AsStringDefault(recorder, "NAME", "<name>")
+
+			data, err := recorder.JSON()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(data).To(MatchJSON(`[
+				{
+					"key": "NAME",
+					"accessor": "AsStringDefault",
+					"default": "<name>",
+					"hasDefault": true,
+					"defined": false
+				},
+				{
+					"key": "WORKERS",
+					"accessor": "AsIntBetween",
+					"hasDefault": false,
+					"min": "1",
+					"max": "100",
+					"defined": true
+				}
+			]`))
+		})
+	})
+
+	Describe("func Markdown()", func() {
+		It("returns a Markdown table that documents the keys", func() {
+			AsIntBetween(recorder, "WORKERS", 1, 100)
+			TryAsIntDefault(recorder, "WORKERS", 4)
+			AsStringDefault(recorder, "NAME", "<name>")
+
+			Expect(recorder.Markdown()).To(Equal(
+				"| Name | Type | Default | Description |\n" +
+					"| ---- | ---- | ------- | ----------- |\n" +
+					"| `NAME` | `AsStringDefault()` | `<name>` |  |\n" +
+					"| `WORKERS` | `AsIntBetween(), TryAsIntDefault()` | `4` | Must be between 1 and 100 (inclusive). |\n",
+			))
+		})
+	})
+})
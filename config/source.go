@@ -8,4 +8,34 @@ type source interface {
 	AsPath() (string, io.Closer, error)
 	AsString() (string, error)
 	AsBytes() ([]byte, error)
+
+	// Source returns a human-readable description of where the value was
+	// obtained, or an empty string if it is unknown.
+	Source() string
+}
+
+// describedSource is an implementation of the source interface that wraps
+// another source with a description of where the value was obtained.
+type describedSource struct {
+	source
+	desc string
+}
+
+func (s *describedSource) Source() string {
+	if inner := s.source.Source(); inner != "" {
+		return s.desc + " (" + inner + ")"
+	}
+
+	return s.desc
+}
+
+// described returns v with a description of where it was obtained.
+//
+// If v is the zero-value or desc is empty, v is returned unchanged.
+func described(v Value, desc string) Value {
+	if v.src == nil || desc == "" {
+		return v
+	}
+
+	return Value{&describedSource{v.src, desc}}
 }
@@ -0,0 +1,131 @@
+package config_test
+
+import (
+	"os"
+	"strings"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// readCountingBucket is a Bucket that counts the number of times each key is
+// read.
+type readCountingBucket struct {
+	Bucket
+	reads map[string]int
+}
+
+func (b readCountingBucket) Get(k string) Value {
+	b.reads[k]++
+	return b.Bucket.Get(k)
+}
+
+var _ = Describe("func Value.Source()", func() {
+	AfterEach(func() {
+		os.Unsetenv("DODECA_TEST")
+		os.Unsetenv("DODECA_TEST__DATASOURCE")
+		os.Unsetenv("DODECA_TEST_FILE")
+	})
+
+	It("returns an empty string for the zero-value", func() {
+		Expect(Value{}.Source()).To(BeEmpty())
+	})
+
+	It("returns an empty string for values with an unknown source", func() {
+		Expect(String("<value>").Source()).To(BeEmpty())
+		Expect(Bytes([]byte("<value>")).Source()).To(BeEmpty())
+	})
+
+	It("describes file values", func() {
+		Expect(File("./testdata/example.json").Source()).To(Equal("file ./testdata/example.json"))
+	})
+
+	When("the value is obtained from the environment", func() {
+		It("describes the environment variable", func() {
+			os.Setenv("DODECA_TEST", "<value>")
+
+			v := Environment().Get("DODECA_TEST")
+			Expect(v.Source()).To(Equal("environment variable DODECA_TEST"))
+		})
+
+		It("describes the data-source of encoded values", func() {
+			os.Setenv("DODECA_TEST", "PHZhbHVlPg==")
+			os.Setenv("DODECA_TEST__DATASOURCE", "string:base64")
+
+			v := Environment().Get("DODECA_TEST")
+			Expect(v.Source()).To(Equal("environment variable DODECA_TEST (string:base64)"))
+		})
+
+		It("describes the file of file values", func() {
+			os.Setenv("DODECA_TEST", "./testdata/example.json")
+			os.Setenv("DODECA_TEST__DATASOURCE", "file")
+
+			v := Environment().Get("DODECA_TEST")
+			Expect(v.Source()).To(Equal("environment variable DODECA_TEST (file ./testdata/example.json)"))
+		})
+
+		It("describes the _FILE variable of values obtained via FileSuffix()", func() {
+			os.Setenv("DODECA_TEST_FILE", "./testdata/example.json")
+
+			v := FileSuffix(Environment()).Get("DODECA_TEST")
+			Expect(v.Source()).To(Equal("environment variable DODECA_TEST_FILE (file ./testdata/example.json)"))
+		})
+	})
+
+	When("the value is obtained from a dotenv file", func() {
+		It("describes the path and line number", func() {
+			b, err := DotEnv("./testdata/example.env")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(b.Get("FOO").Source()).To(Equal("line 2 of ./testdata/example.env"))
+			Expect(b.Get("BAR").Source()).To(Equal("line 3 of ./testdata/example.env"))
+		})
+
+		It("describes the line number when there is no path", func() {
+			b, err := DotEnvReader(strings.NewReader("\n\nKEY=value\n"))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(b.Get("KEY").Source()).To(Equal("line 3"))
+		})
+	})
+})
+
+var _ = Describe("value provenance in errors", func() {
+	It("includes the source of an invalid value", func() {
+		b, err := DotEnvReader(strings.NewReader("PORT=80a\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = TryAsInt(b, "PORT")
+		Expect(err).To(Equal(InvalidValue{
+			Key:         "PORT",
+			Value:       "80a",
+			Explanation: "expected an integer between -9223372036854775808 and 9223372036854775807 (inclusive)",
+			Source:      "line 1",
+		}))
+	})
+
+	It("includes the source of a suggested key", func() {
+		b, err := DotEnvReader(strings.NewReader("PROT=80\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = TryAsInt(b, "PORT")
+		Expect(err).To(MatchError("PORT is not defined, did you mean PROT (from line 1)?"))
+	})
+
+	It("describes the value that was read, without reading it again", func() {
+		parent, err := DotEnvReader(strings.NewReader("LIMIT=<invalid>\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		b := readCountingBucket{parent, map[string]int{}}
+
+		_, err = TryAsInt64(b, "LIMIT")
+		Expect(err).To(MatchError(InvalidValue{
+			Key:         "LIMIT",
+			Value:       "<invalid>",
+			Explanation: "expected an integer between -9223372036854775808 and 9223372036854775807 (inclusive)",
+			Source:      "line 1",
+		}))
+		Expect(b.reads).To(Equal(map[string]int{"LIMIT": 1}))
+	})
+})
@@ -0,0 +1,348 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+)
+
+// Spec is a registry of the keys used by an application.
+//
+// Declaring keys up-front allows all of them to be validated at once, such
+// that every problem with the configuration can be reported together, rather
+// than one at a time as each value is first used.
+//
+// The zero-value is an empty spec, ready to use.
+type Spec struct {
+	m     sync.Mutex
+	keys  []specKey
+	index map[string]specKey
+}
+
+// KeySpec describes a key declared in a Spec.
+type KeySpec struct {
+	// Name is the name of the key.
+	Name string
+
+	// Type is the name of the Go type that the value is parsed as.
+	Type string
+
+	// Description is a human-readable description of the key.
+	Description string
+
+	// Default is a string representation of the default value, if any.
+	Default string
+
+	// HasDefault indicates whether the key has a default value.
+	HasDefault bool
+
+	// Min and Max are string representations of the range of permitted
+	// values, as specified by the WithRange() option. They are empty if there
+	// is no range.
+	Min, Max string
+
+	// Required indicates whether the key must be defined. A key is required
+	// unless it has a default value or is declared using Key.Optional().
+	Required bool
+
+	// Sensitive indicates whether the value must not be disclosed, such as in
+	// error messages.
+	Sensitive bool
+}
+
+// specKey is the non-generic interface to a Key.
+type specKey interface {
+	spec() KeySpec
+	validate(b Bucket) (commit func(), err KeyError)
+}
+
+// Key is a key declared in a Spec.
+//
+// Its value is available via Get() and Lookup() once Spec.Validate() has
+// succeeded.
+type Key[T any] struct {
+	name        string
+	description string
+	opts        []Option[T]
+	resolved    options[T]
+	optional    bool
+	sensitive   bool
+	state       atomic.Pointer[keyState[T]]
+}
+
+// keyState is the validated value of a Key.
+type keyState[T any] struct {
+	value   T
+	defined bool
+}
+
+// sensitivePlaceholder is used in place of the values of sensitive keys in
+// errors.
+const sensitivePlaceholder = "<sensitive>"
+
+// Declare adds a key named k to the spec.
+//
+// The value is parsed as per Get(), using the given options. The key is
+// required unless a default value is specified using the WithDefault() option
+// or it is marked as optional using Key.Optional().
+//
+// It panics if k is already declared, or with an UnsupportedType error if there
+// is no parser registered for T.
+func Declare[T any](s *Spec, k, desc string, opts ...Option[T]) *Key[T] {
+	if t := typeOf[T](); !hasParser(t) {
+		panic(UnsupportedType{
+			Key:  k,
+			Type: typeName(t),
+		})
+	}
+
+	key := &Key[T]{
+		name:        k,
+		description: desc,
+		opts:        opts,
+	}
+
+	for _, opt := range opts {
+		opt(&key.resolved)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if _, ok := s.index[k]; ok {
+		panic(fmt.Sprintf("%s is already declared", k))
+	}
+
+	if s.index == nil {
+		s.index = map[string]specKey{}
+	}
+
+	s.keys = append(s.keys, key)
+	s.index[k] = key
+
+	return key
+}
+
+// Keys returns the keys declared in the spec, in the order they were declared.
+func (s *Spec) Keys() []KeySpec {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	specs := make([]KeySpec, len(s.keys))
+	for i, k := range s.keys {
+		specs[i] = k.spec()
+	}
+
+	return specs
+}
+
+// Validate checks the values of all of the keys in the spec.
+//
+// If there are no problems, the values become available via the Get() and
+// Lookup() methods of each key. Otherwise, it returns the problems with every
+// key, in the order the keys were declared, and the values available via the
+// keys are unchanged.
+//
+// The values of sensitive keys are omitted from the returned errors.
+func (s *Spec) Validate(b Bucket) []KeyError {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var (
+		errs    []KeyError
+		commits []func()
+	)
+
+	for _, k := range s.keys {
+		commit, err := k.validate(b)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			commits = append(commits, commit)
+		}
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	for _, commit := range commits {
+		commit()
+	}
+
+	return nil
+}
+
+// FormatErrors returns a table describing each of the given errors, as
+// returned by Validate().
+func (s *Spec) FormatErrors(errs []KeyError) string {
+	var buf bytes.Buffer
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tPROBLEM\tDESCRIPTION")
+
+	for _, err := range errs {
+		var desc string
+
+		s.m.Lock()
+		if k, ok := s.index[err.ConfigKey()]; ok {
+			desc = k.spec().Description
+		}
+		s.m.Unlock()
+
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\n",
+			err.ConfigKey(),
+			describeProblem(err),
+			desc,
+		)
+	}
+
+	w.Flush()
+
+	return buf.String()
+}
+
+// describeProblem returns a description of err that does not include the key.
+func describeProblem(err KeyError) string {
+	switch e := err.(type) {
+	case NotDefined:
+		return "not defined"
+	case InvalidValue:
+		if e.Source != "" {
+			return fmt.Sprintf("invalid value (%#v) from %s: %s", e.Value, e.Source, e.Explanation)
+		}
+		return fmt.Sprintf("invalid value (%#v): %s", e.Value, e.Explanation)
+	case InvalidDefaultValue:
+		if e.Source != "" {
+			return fmt.Sprintf("invalid default value (%#v) from %s: %s", e.DefaultValue, e.Source, e.Explanation)
+		}
+		return fmt.Sprintf("invalid default value (%#v): %s", e.DefaultValue, e.Explanation)
+	case Unreadable:
+		return fmt.Sprintf("cannot be read: %s", e.Cause)
+	default:
+		return err.Error()
+	}
+}
+
+// Name returns the name of the key.
+func (k *Key[T]) Name() string {
+	return k.name
+}
+
+// Optional marks the key as optional, such that it is not an error for it to
+// be undefined.
+//
+// It returns k, so that it may be called in the same expression as Declare().
+func (k *Key[T]) Optional() *Key[T] {
+	k.optional = true
+	return k
+}
+
+// Sensitive marks the key as sensitive, such that its value is not disclosed
+// in errors.
+//
+// It returns k, so that it may be called in the same expression as Declare().
+func (k *Key[T]) Sensitive() *Key[T] {
+	k.sensitive = true
+	return k
+}
+
+// Get returns the value of the key.
+//
+// If the key is optional and undefined, it returns the zero-value of T. It
+// panics if the spec has not been validated successfully.
+func (k *Key[T]) Get() T {
+	v, _ := k.Lookup()
+	return v
+}
+
+// Lookup returns the value of the key.
+//
+// ok is false if the key is optional and undefined. It panics if the spec has
+// not been validated successfully.
+func (k *Key[T]) Lookup() (_ T, ok bool) {
+	s := k.state.Load()
+	if s == nil {
+		panic(fmt.Sprintf("%s has not been validated", k.name))
+	}
+
+	return s.value, s.defined
+}
+
+func (k *Key[T]) spec() KeySpec {
+	s := KeySpec{
+		Name:        k.name,
+		Type:        typeName(typeOf[T]()),
+		Description: k.description,
+		HasDefault:  k.resolved.hasDefault,
+		Min:         k.resolved.min,
+		Max:         k.resolved.max,
+		Required:    !k.resolved.hasDefault && !k.optional,
+		Sensitive:   k.sensitive,
+	}
+
+	if s.HasDefault {
+		if buf, ok := any(k.resolved.def).([]byte); ok {
+			s.Default = string(buf)
+		} else {
+			s.Default = fmt.Sprint(k.resolved.def)
+		}
+	}
+
+	return s
+}
+
+// bytesType is the type of byte-slice values, which typeName() reports using
+// the "[]byte" alias, rather than "[]uint8".
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// typeName returns the name of t as it would appear in Go source code.
+func typeName(t reflect.Type) string {
+	if t == bytesType {
+		return "[]byte"
+	}
+
+	return t.String()
+}
+
+func (k *Key[T]) validate(b Bucket) (func(), KeyError) {
+	if k.optional && !k.resolved.hasDefault {
+		if x := b.Get(k.name); x.IsZero() {
+			return func() {
+				k.state.Store(&keyState[T]{})
+			}, nil
+		}
+	}
+
+	v, err := TryGet(b, k.name, k.opts...)
+	if err != nil {
+		return nil, k.redact(err.(KeyError))
+	}
+
+	return func() {
+		k.state.Store(&keyState[T]{v, true})
+	}, nil
+}
+
+// redact removes the value from err if the key is sensitive.
+func (k *Key[T]) redact(err KeyError) KeyError {
+	if !k.sensitive {
+		return err
+	}
+
+	switch e := err.(type) {
+	case InvalidValue:
+		e.Value = sensitivePlaceholder
+		return e
+	case InvalidDefaultValue:
+		e.DefaultValue = sensitivePlaceholder
+		return e
+	default:
+		return err
+	}
+}
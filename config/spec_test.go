@@ -0,0 +1,210 @@
+package config_test
+
+import (
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("type Spec", func() {
+	var (
+		spec     *Spec
+		timeout  *Key[time.Duration]
+		workers  *Key[int]
+		password *Key[string]
+		debug    *Key[bool]
+	)
+
+	BeforeEach(func() {
+		spec = &Spec{}
+
+		timeout = Declare(
+			spec,
+			"HTTP_TIMEOUT",
+			"the timeout for HTTP requests",
+			WithDefault(30*time.Second),
+			WithRange(1*time.Second, 5*time.Minute),
+		)
+
+		workers = Declare(
+			spec,
+			"WORKERS",
+			"the number of workers",
+			WithRange(1, 100),
+		)
+
+		password = Declare[string](
+			spec,
+			"DB_PASSWORD",
+			"the database password",
+		).Sensitive()
+
+		debug = Declare[bool](
+			spec,
+			"DEBUG",
+			"enable debug logging",
+		).Optional()
+	})
+
+	Describe("func Declare()", func() {
+		It("panics if the key is already declared", func() {
+			Expect(func() {
+				Declare[int](spec, "WORKERS", "<desc>")
+			}).To(PanicWith("WORKERS is already declared"))
+		})
+
+		It("panics if there is no parser registered for the type", func() {
+			Expect(func() {
+				Declare[struct{}](spec, "UNSUPPORTED", "<desc>")
+			}).To(PanicWith(UnsupportedType{
+				Key:  "UNSUPPORTED",
+				Type: "struct {}",
+			}))
+
+			Expect(spec.Keys()).NotTo(ContainElement(
+				HaveField("Name", "UNSUPPORTED"),
+			))
+		})
+	})
+
+	Describe("func Keys()", func() {
+		It("returns the declared keys in order", func() {
+			Expect(spec.Keys()).To(Equal([]KeySpec{
+				{
+					Name:        "HTTP_TIMEOUT",
+					Type:        "time.Duration",
+					Description: "the timeout for HTTP requests",
+					Default:     "30s",
+					HasDefault:  true,
+					Min:         "1s",
+					Max:         "5m0s",
+				},
+				{
+					Name:        "WORKERS",
+					Type:        "int",
+					Description: "the number of workers",
+					Min:         "1",
+					Max:         "100",
+					Required:    true,
+				},
+				{
+					Name:        "DB_PASSWORD",
+					Type:        "string",
+					Description: "the database password",
+					Required:    true,
+					Sensitive:   true,
+				},
+				{
+					Name:        "DEBUG",
+					Type:        "bool",
+					Description: "enable debug logging",
+				},
+			}))
+		})
+	})
+
+	Describe("func Validate()", func() {
+		It("makes the values available via the keys", func() {
+			errs := spec.Validate(Map{
+				"WORKERS":     String("8"),
+				"DB_PASSWORD": String("<password>"),
+			})
+			Expect(errs).To(BeEmpty())
+
+			Expect(timeout.Get()).To(Equal(30 * time.Second))
+			Expect(workers.Get()).To(Equal(8))
+			Expect(password.Get()).To(Equal("<password>"))
+
+			v, ok := debug.Lookup()
+			Expect(v).To(BeFalse())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("returns all of the problems at once", func() {
+			errs := spec.Validate(Map{
+				"HTTP_TIMEOUT": String("1h"),
+				"WORKERS":      String("<workers>"),
+				"DEBUG":        String("<debug>"),
+			})
+
+			Expect(errs).To(Equal([]KeyError{
+				InvalidValue{
+					Key:         "HTTP_TIMEOUT",
+					Value:       "1h",
+					Explanation: "expected a value between 1s and 5m0s (inclusive)",
+				},
+				InvalidValue{
+					Key:         "WORKERS",
+					Value:       "<workers>",
+					Explanation: "expected an integer between -9223372036854775808 and 9223372036854775807 (inclusive)",
+				},
+				NotDefined{Key: "DB_PASSWORD"},
+				InvalidValue{
+					Key:         "DEBUG",
+					Value:       "<debug>",
+					Explanation: `expected a boolean ("true", "false", "yes", "no", "on" or "off")`,
+				},
+			}))
+		})
+
+		It("omits the values of sensitive keys from errors", func() {
+			spec := &Spec{}
+			Declare(spec, "PIN", "<desc>", WithRange(1000, 9999)).Sensitive()
+
+			errs := spec.Validate(Map{
+				"PIN": String("12345"),
+			})
+			Expect(errs).To(Equal([]KeyError{
+				InvalidValue{
+					Key:         "PIN",
+					Value:       "<sensitive>",
+					Explanation: "expected a value between 1000 and 9999 (inclusive)",
+				},
+			}))
+		})
+
+		It("does not change the values if validation fails", func() {
+			errs := spec.Validate(Map{
+				"WORKERS":     String("8"),
+				"DB_PASSWORD": String("<password>"),
+			})
+			Expect(errs).To(BeEmpty())
+
+			errs = spec.Validate(Map{
+				"WORKERS": String("16"),
+			})
+			Expect(errs).To(HaveLen(1))
+
+			Expect(workers.Get()).To(Equal(8))
+		})
+	})
+
+	Describe("func FormatErrors()", func() {
+		It("returns a table of the problems", func() {
+			errs := spec.Validate(Map{
+				"HTTP_TIMEOUT": String("1h"),
+				"WORKERS":      String("8"),
+			})
+
+			Expect(spec.FormatErrors(errs)).To(Equal(
+				"KEY           PROBLEM                                                                 DESCRIPTION\n" +
+					"HTTP_TIMEOUT  invalid value (\"1h\"): expected a value between 1s and 5m0s (inclusive)  the timeout for HTTP requests\n" +
+					"DB_PASSWORD   not defined                                                             the database password\n",
+			))
+		})
+	})
+
+	Describe("type Key", func() {
+		It("panics if the spec has not been validated", func() {
+			Expect(func() {
+				workers.Get()
+			}).To(PanicWith("WORKERS has not been validated"))
+		})
+
+		It("returns its name", func() {
+			Expect(workers.Name()).To(Equal("WORKERS"))
+		})
+	})
+})
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"fmt"
 	"io"
 	"io/ioutil"
 	"strings"
@@ -47,40 +46,85 @@ func (s *stringSource) AsBytes() ([]byte, error) {
 	return []byte(s.value), nil
 }
 
+func (s *stringSource) Source() string {
+	return ""
+}
+
 // AsString returns the string representation of the value associated with k or
 // panics if unable to do so.
 func AsString(b Bucket, k string) string {
-	if v, ok := asString(b, k); ok {
-		return v
-	}
+	return asString(b, k, accessor{"AsString", false})
+}
 
-	panic(NotDefined{k})
+// TryAsString returns the string representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsString(b Bucket, k string) (string, error) {
+	return try(func() string {
+		return asString(b, k, accessor{"TryAsString", false})
+	})
 }
 
 // AsStringDefault returns the string representation of the value associated
 // with k, or the default value v if k is undefined.
 func AsStringDefault(b Bucket, k string, v string) string {
-	if x, ok := asString(b, k); ok {
-		return x
-	}
+	return asStringDefault(b, k, accessor{"AsStringDefault", false}, v)
+}
 
-	return v
+// TryAsStringDefault returns the string representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsStringDefault(b Bucket, k string, v string) (string, error) {
+	return try(func() string {
+		return asStringDefault(b, k, accessor{"TryAsStringDefault", false}, v)
+	})
 }
 
-func asString(b Bucket, k string) (string, bool) {
-	x := b.Get(k)
+func tryAsString(
+	b Bucket,
+	k string,
+	a accessor,
+) (string, bool) {
+	x := access(b, k, a)
 
 	if x.IsZero() {
 		return "", false
 	}
 
-	return mustAsString(k, x), true
+	return mustAsString(qualify(b, k), x), true
+}
+
+func asString(
+	b Bucket,
+	k string,
+	a accessor,
+) string {
+	if v, ok := tryAsString(b, k, a); ok {
+		return v
+	}
+
+	panic(notDefined(b, k))
+}
+
+func asStringDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	d string,
+) string {
+	annotateDefault(b, k, a, d)
+
+	if v, ok := tryAsString(b, k, a); ok {
+		return v
+	}
+
+	return d
 }
 
 func mustAsString(k string, v Value) string {
 	s, err := v.AsString()
 	if err != nil {
-		panic(fmt.Sprintf("cannot read %s: %s", k, err))
+		panic(unreadable(k, err))
 	}
 
 	return s
@@ -0,0 +1,93 @@
+package config
+
+import "strings"
+
+// Sub returns a bucket containing only those keys in b that begin with the
+// given prefix.
+//
+// The keys in the returned bucket do not include the prefix. For example, if
+// the prefix is "DB_", the key "DB_HOST" in b is accessed as "HOST" in the
+// returned bucket.
+//
+// Errors produced by the typed functions, such as AsInt(), report the
+// fully-qualified key, including the prefix.
+func Sub(b Bucket, prefix string) Bucket {
+	return sub{b, prefix}
+}
+
+// sub is an implementation of Bucket that exposes the keys of another bucket
+// that begin with a specific prefix.
+type sub struct {
+	parent Bucket
+	prefix string
+}
+
+// Get returns the value associated with the given key.
+//
+// If they key is not defined, it returns a zero-value.
+func (b sub) Get(k string) Value {
+	return b.parent.Get(b.prefix + k)
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// If the key is not defined, it returns a value with the content of v.
+func (b sub) GetDefault(k string, v string) Value {
+	return b.parent.GetDefault(b.prefix+k, v)
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+func (b sub) Each(fn EachFunc) bool {
+	return b.parent.Each(
+		func(k string, v Value) bool {
+			if len(k) <= len(b.prefix) || !strings.HasPrefix(k, b.prefix) {
+				return true
+			}
+
+			return fn(k[len(b.prefix):], v)
+		},
+	)
+}
+
+// qualifiedKey returns the key used to identify k in the parent bucket.
+func (b sub) qualifiedKey(k string) string {
+	return qualify(b.parent, b.prefix+k)
+}
+
+// qualifier is an interface for buckets that expose keys by some name other
+// than the one used by the underlying source.
+//
+// Buckets that wrap other buckets implement qualifier by qualifying the key
+// within the wrapped bucket, such that the prefix of a Sub() bucket is not
+// lost when it is wrapped.
+type qualifier interface {
+	qualifiedKey(k string) string
+}
+
+// qualify returns the fully-qualified name of the key k within b.
+//
+// This is the name that is used to report errors relating to k.
+func qualify(b Bucket, k string) string {
+	if q, ok := b.(qualifier); ok {
+		return q.qualifiedKey(k)
+	}
+
+	return k
+}
+
+// access returns the value associated with k, recording that it was accessed
+// by a if the parent bucket records accesses.
+func (b sub) access(k string, a accessor) Value {
+	return access(b.parent, b.prefix+k, a)
+}
+
+// annotate updates the record of k being accessed by a, if the parent bucket
+// records accesses.
+func (b sub) annotate(k string, a accessor, fn func(*Access)) {
+	annotate(b.parent, b.prefix+k, a, fn)
+}
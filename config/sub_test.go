@@ -0,0 +1,153 @@
+package config_test
+
+import (
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func Sub()", func() {
+	var bucket Bucket
+
+	BeforeEach(func() {
+		bucket = Sub(
+			Map{
+				"DB_HOST": String("<host>"),
+				"DB_PORT": String("<port>"),
+				"DB_":     String("<empty>"),
+				"HTTP_DB": String("<other>"),
+			},
+			"DB_",
+		)
+	})
+
+	Describe("func Get()", func() {
+		It("returns the value associated with the prefixed key", func() {
+			v := bucket.Get("HOST")
+			Expect(v).To(Equal(String("<host>")))
+		})
+
+		It("returns the zero-value if the key is undefined", func() {
+			v := bucket.Get("DB_HOST")
+			Expect(v.IsZero()).To(BeTrue())
+		})
+	})
+
+	Describe("func GetDefault()", func() {
+		It("returns the value associated with the prefixed key", func() {
+			v := bucket.GetDefault("HOST", "<default>")
+			Expect(v).To(Equal(String("<host>")))
+		})
+
+		It("returns the default value if the key is undefined", func() {
+			v := bucket.GetDefault("USER", "<default>")
+			Expect(v).To(Equal(String("<default>")))
+		})
+	})
+
+	Describe("func Each()", func() {
+		It("invokes the function for each key with the prefix, with the prefix removed", func() {
+			calls := map[string]Value{}
+
+			fn := func(k string, v Value) bool {
+				calls[k] = v
+				return true
+			}
+
+			Expect(bucket.Each(fn)).To(BeTrue())
+			Expect(calls).To(Equal(map[string]Value{
+				"HOST": String("<host>"),
+				"PORT": String("<port>"),
+			}))
+		})
+
+		It("stops iterating if the function returns false", func() {
+			count := 0
+
+			fn := func(k string, v Value) bool {
+				count++
+				return false
+			}
+
+			Expect(bucket.Each(fn)).To(BeFalse())
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	Context("when used with the typed functions", func() {
+		It("reports the fully-qualified key in NotDefined errors", func() {
+			Expect(func() {
+				AsString(bucket, "USER")
+			}).To(PanicWith(NotDefined{Key: "DB_USER"}))
+		})
+
+		It("reports the fully-qualified key in InvalidValue errors", func() {
+			Expect(func() {
+				AsInt(bucket, "PORT")
+			}).To(PanicWith(InvalidValue{
+				Key:         "DB_PORT",
+				Value:       "<port>",
+				Explanation: `expected an integer between -9223372036854775808 and 9223372036854775807 (inclusive)`,
+			}))
+		})
+
+		It("reports the fully-qualified key in InvalidDefaultValue errors", func() {
+			Expect(func() {
+				AsIntDefaultBetween(bucket, "TIMEOUT", 100, 0, 10)
+			}).To(PanicWith(InvalidDefaultValue{
+				Key:          "DB_TIMEOUT",
+				DefaultValue: "100",
+				Explanation:  `expected an integer between 0 and 10 (inclusive)`,
+			}))
+		})
+
+		It("reports the fully-qualified key when sub-buckets are nested", func() {
+			b := Sub(Sub(Map{}, "APP_"), "DB_")
+
+			Expect(func() {
+				AsString(b, "HOST")
+			}).To(PanicWith(NotDefined{Key: "APP_DB_HOST"}))
+		})
+
+		It("reports the fully-qualified key when wrapped by Layered()", func() {
+			b := Layered(Map{"OTHER": String("<other>")}, bucket)
+
+			Expect(func() {
+				AsString(b, "USER")
+			}).To(PanicWith(NotDefined{Key: "DB_USER"}))
+
+			_, err := TryAsInt(b, "PORT")
+			Expect(err).To(BeAssignableToTypeOf(InvalidValue{}))
+			Expect(err.(InvalidValue).Key).To(Equal("DB_PORT"))
+		})
+
+		It("reports the key of the layer that supplies the value when wrapped by Layered()", func() {
+			b := Layered(bucket, Map{"PORT": String("<override>")})
+
+			_, err := TryAsInt(b, "PORT")
+			Expect(err).To(BeAssignableToTypeOf(InvalidValue{}))
+			Expect(err.(InvalidValue).Key).To(Equal("PORT"))
+		})
+
+		It("reports the fully-qualified key when wrapped by FileSuffix()", func() {
+			b := FileSuffix(
+				Sub(
+					Map{
+						"DB_PORT":      String("<port>"),
+						"DB_PORT_FILE": String("/path/to/port"),
+					},
+					"DB_",
+				),
+			)
+
+			Expect(func() {
+				AsString(b, "USER")
+			}).To(PanicWith(NotDefined{Key: "DB_USER"}))
+
+			_, err := TryAsString(b, "PORT")
+			Expect(err).To(BeAssignableToTypeOf(InvalidValue{}))
+			Expect(err.(InvalidValue).Key).To(Equal("DB_PORT_FILE"))
+			Expect(err.(InvalidValue).Explanation).To(Equal("expected DB_PORT to be undefined when DB_PORT_FILE is defined"))
+		})
+	})
+})
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// notDefined returns a NotDefined error for k, suggesting a similarly named
+// key that is defined in b, if any.
+func notDefined(b Bucket, k string) NotDefined {
+	var candidates []string
+	sources := map[string]string{}
+
+	b.Each(func(c string, v Value) bool {
+		if !v.IsZero() {
+			candidates = append(candidates, c)
+			sources[c] = v.Source()
+		}
+		return true
+	})
+
+	e := NotDefined{Key: qualify(b, k)}
+
+	if s := suggest(k, candidates); s != "" {
+		e.Suggestion = qualify(b, s)
+		e.SuggestionSource = sources[s]
+	}
+
+	return e
+}
+
+// FindUnused returns an Unused error for each key in b that begins with
+// prefix but is not one of the known keys.
+//
+// known is typically the set of keys declared in a Spec or recorded by a
+// Recorder. The errors are sorted by key.
+//
+// It is intended to detect misspelled keys, which would otherwise cause
+// default values to be used silently. Each error suggests a similarly named
+// known key, if any.
+func FindUnused(b Bucket, prefix string, known []string) []KeyError {
+	isKnown := make(map[string]struct{}, len(known))
+	for _, k := range known {
+		isKnown[k] = struct{}{}
+	}
+
+	var errs []KeyError
+
+	b.Each(func(k string, v Value) bool {
+		if v.IsZero() || !strings.HasPrefix(k, prefix) {
+			return true
+		}
+
+		if _, ok := isKnown[k]; !ok {
+			errs = append(errs, Unused{
+				Key:        qualify(b, k),
+				Suggestion: suggest(k, known),
+			})
+		}
+
+		return true
+	})
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].ConfigKey() < errs[j].ConfigKey()
+	})
+
+	return errs
+}
+
+// Unused returns an Unused error for each key in b that begins with prefix but
+// is not declared in the spec.
+//
+// See FindUnused() for more information.
+func (s *Spec) Unused(b Bucket, prefix string) []KeyError {
+	var known []string
+	for _, k := range s.Keys() {
+		known = append(known, k.Name)
+	}
+
+	return FindUnused(b, prefix, known)
+}
+
+// Unused returns an Unused error for each key in the underlying bucket that
+// begins with prefix but has not been accessed.
+//
+// See FindUnused() for more information.
+func (r *Recorder) Unused(prefix string) []KeyError {
+	var known []string
+	for _, a := range r.Accesses() {
+		known = append(known, a.Key)
+	}
+
+	return FindUnused(r.parent, prefix, known)
+}
+
+// suggest returns the candidate that is most similar to k, or an empty string
+// if none of the candidates are similar enough to be a likely misspelling.
+//
+// Keys are compared without regard to case.
+func suggest(k string, candidates []string) string {
+	max := len(k) / 3
+	if max < 1 {
+		max = 1
+	}
+
+	var (
+		best     string
+		bestDist = max + 1
+	)
+
+	for _, c := range candidates {
+		if c == k {
+			continue
+		}
+
+		d := editDistance(strings.ToUpper(k), strings.ToUpper(c))
+		if d < bestDist || (d == bestDist && c < best) {
+			best = c
+			bestDist = d
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the number of single-character insertions, deletions,
+// substitutions and transpositions of adjacent characters required to change
+// a into b.
+func editDistance(a, b string) int {
+	x, y := []rune(a), []rune(b)
+
+	// d[i][j] is the distance between the first i runes of x and the first j
+	// runes of y.
+	d := make([][]int, len(x)+1)
+	for i := range d {
+		d[i] = make([]int, len(y)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(
+				d[i-1][j]+1,      // deletion
+				d[i][j-1]+1,      // insertion
+				d[i-1][j-1]+cost, // substitution
+			)
+
+			if i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1) // transposition
+			}
+		}
+	}
+
+	return d[len(x)][len(y)]
+}
@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("type NotDefined (suggestions)", func() {
+	bucket := Map{
+		"HTPP_TIMEOUT": String("10s"),
+		"DB_HOST":      String("<host>"),
+		"PORT":         String("8080"),
+	}
+
+	It("suggests a similarly named key that is defined", func() {
+		_, err := TryAsDuration(bucket, "HTTP_TIMEOUT")
+		Expect(err).To(Equal(NotDefined{
+			Key:        "HTTP_TIMEOUT",
+			Suggestion: "HTPP_TIMEOUT",
+		}))
+		Expect(err).To(MatchError("HTTP_TIMEOUT is not defined, did you mean HTPP_TIMEOUT?"))
+	})
+
+	It("suggests keys that differ only by case", func() {
+		_, err := TryAsString(bucket, "db_host")
+		Expect(err).To(Equal(NotDefined{
+			Key:        "db_host",
+			Suggestion: "DB_HOST",
+		}))
+	})
+
+	It("does not suggest keys that are too dissimilar", func() {
+		_, err := TryAsString(bucket, "HOST")
+		Expect(err).To(Equal(NotDefined{Key: "HOST"}))
+	})
+
+	It("suggests fully-qualified keys when accessed via Sub()", func() {
+		_, err := TryAsString(Sub(bucket, "DB_"), "HOTS")
+		Expect(err).To(Equal(NotDefined{
+			Key:        "DB_HOTS",
+			Suggestion: "DB_HOST",
+		}))
+	})
+})
+
+var _ = Describe("func FindUnused()", func() {
+	bucket := Map{
+		"MYAPP_HTPP_TIMEOUT": String("10s"),
+		"MYAPP_PORT":         String("8080"),
+		"MYAPP_UNRELATED":    String("<value>"),
+		"MYAPP_EMPTY":        Value{},
+		"PATH":               String("/usr/bin"),
+	}
+
+	It("returns an error for each unused key with the prefix", func() {
+		errs := FindUnused(
+			bucket,
+			"MYAPP_",
+			[]string{"MYAPP_HTTP_TIMEOUT", "MYAPP_PORT"},
+		)
+
+		Expect(errs).To(Equal([]KeyError{
+			Unused{
+				Key:        "MYAPP_HTPP_TIMEOUT",
+				Suggestion: "MYAPP_HTTP_TIMEOUT",
+			},
+			Unused{
+				Key: "MYAPP_UNRELATED",
+			},
+		}))
+	})
+
+	It("returns nil if all keys are used", func() {
+		errs := FindUnused(
+			bucket,
+			"MYAPP_",
+			[]string{"MYAPP_HTPP_TIMEOUT", "MYAPP_PORT", "MYAPP_UNRELATED"},
+		)
+
+		Expect(errs).To(BeEmpty())
+	})
+
+	Describe("func Spec.Unused()", func() {
+		It("treats the declared keys as known", func() {
+			spec := &Spec{}
+			Declare[int](spec, "MYAPP_PORT", "<desc>")
+			Declare[string](spec, "MYAPP_UNRELATED", "<desc>")
+
+			Expect(spec.Unused(bucket, "MYAPP_")).To(Equal([]KeyError{
+				Unused{Key: "MYAPP_HTPP_TIMEOUT"},
+			}))
+		})
+	})
+
+	Describe("func Recorder.Unused()", func() {
+		It("treats the accessed keys as known", func() {
+			r := Record(bucket)
+			AsNetworkPort(r, "MYAPP_PORT")
+			AsDurationDefault(r, "MYAPP_HTTP_TIMEOUT", 0)
+
+			Expect(r.Unused("MYAPP_")).To(Equal([]KeyError{
+				Unused{
+					Key:        "MYAPP_HTPP_TIMEOUT",
+					Suggestion: "MYAPP_HTTP_TIMEOUT",
+				},
+				Unused{
+					Key: "MYAPP_UNRELATED",
+				},
+			}))
+		})
+	})
+})
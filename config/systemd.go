@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SystemdCredentials returns a bucket that produces configuration values from
+// the credentials that systemd passes to a service using the LoadCredential=,
+// SetCredential= and related unit settings.
+//
+// Each credential is a key, the value of which is the content of the file
+// with the same name in the directory given by the $CREDENTIALS_DIRECTORY
+// environment variable. Value.AsPath() returns the path to the credential
+// file.
+//
+// If $CREDENTIALS_DIRECTORY is undefined or does not refer to a directory, all
+// keys are undefined and the Err() method of the returned bucket describes the
+// problem. This allows the bucket to be layered with Environment() such that
+// the same keys may be used both inside and outside of systemd.
+func SystemdCredentials() *SystemdCredentialsBucket {
+	p := os.Getenv("CREDENTIALS_DIRECTORY")
+	if p == "" {
+		return &SystemdCredentialsBucket{
+			err: errors.New("systemd credentials are unavailable: $CREDENTIALS_DIRECTORY is undefined"),
+		}
+	}
+
+	d, err := Directory(p)
+	if err != nil {
+		return &SystemdCredentialsBucket{
+			err: fmt.Errorf("systemd credentials are unavailable: %w", err),
+		}
+	}
+
+	return &SystemdCredentialsBucket{dir: d}
+}
+
+// SystemdCredentialsBucket is an implementation of Bucket that sources values
+// from systemd credentials.
+type SystemdCredentialsBucket struct {
+	dir Bucket
+	err error
+}
+
+// Err returns an error if the credentials directory is unavailable.
+func (b *SystemdCredentialsBucket) Err() error {
+	return b.err
+}
+
+// Get returns the value associated with the given key.
+//
+// If they key is not defined, it returns a zero-value.
+func (b *SystemdCredentialsBucket) Get(k string) Value {
+	if b.err != nil {
+		return Value{}
+	}
+
+	return b.dir.Get(k)
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// If the key is not defined, it returns a value with the content of v.
+func (b *SystemdCredentialsBucket) GetDefault(k string, v string) Value {
+	if b.err != nil {
+		return String(v)
+	}
+
+	return b.dir.GetDefault(k, v)
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+func (b *SystemdCredentialsBucket) Each(fn EachFunc) bool {
+	if b.err != nil {
+		return true
+	}
+
+	return b.dir.Each(fn)
+}
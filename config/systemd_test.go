@@ -0,0 +1,98 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func SystemdCredentials()", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = os.WriteFile(filepath.Join(dir, "DB_PASSWORD"), []byte("<password>"), 0600)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		os.Setenv("CREDENTIALS_DIRECTORY", dir)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CREDENTIALS_DIRECTORY")
+		os.Unsetenv("DB_PASSWORD")
+		os.Unsetenv("DB_USER")
+		os.RemoveAll(dir)
+	})
+
+	It("returns a bucket containing the credentials", func() {
+		bucket := SystemdCredentials()
+		Expect(bucket.Err()).ShouldNot(HaveOccurred())
+
+		v := bucket.Get("DB_PASSWORD")
+		Expect(v.String()).To(Equal("<password>"))
+
+		p, c, err := v.AsPath()
+		Expect(err).ShouldNot(HaveOccurred())
+		defer c.Close()
+		Expect(p).To(Equal(filepath.Join(dir, "DB_PASSWORD")))
+
+		v = bucket.GetDefault("DB_USER", "<default>")
+		Expect(v).To(Equal(String("<default>")))
+
+		var keys []string
+		bucket.Each(func(k string, v Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		Expect(keys).To(ConsistOf("DB_PASSWORD"))
+	})
+
+	It("returns an empty bucket with an error if $CREDENTIALS_DIRECTORY is undefined", func() {
+		os.Unsetenv("CREDENTIALS_DIRECTORY")
+
+		bucket := SystemdCredentials()
+		Expect(bucket.Err()).To(MatchError("systemd credentials are unavailable: $CREDENTIALS_DIRECTORY is undefined"))
+
+		v := bucket.Get("DB_PASSWORD")
+		Expect(v.IsZero()).To(BeTrue())
+
+		v = bucket.GetDefault("DB_PASSWORD", "<default>")
+		Expect(v).To(Equal(String("<default>")))
+
+		Expect(bucket.Each(func(k string, v Value) bool {
+			Fail("unexpected call")
+			return true
+		})).To(BeTrue())
+	})
+
+	It("returns an empty bucket with an error if $CREDENTIALS_DIRECTORY does not exist", func() {
+		os.Setenv("CREDENTIALS_DIRECTORY", filepath.Join(dir, "does-not-exist"))
+
+		bucket := SystemdCredentials()
+		Expect(os.IsNotExist(errors.Unwrap(bucket.Err()))).To(BeTrue())
+
+		v := bucket.Get("DB_PASSWORD")
+		Expect(v.IsZero()).To(BeTrue())
+	})
+
+	It("can be layered with the environment", func() {
+		os.Setenv("DB_PASSWORD", "<env-password>")
+		os.Setenv("DB_USER", "<env-user>")
+
+		bucket := Layered(Environment(), SystemdCredentials())
+		Expect(AsString(bucket, "DB_PASSWORD")).To(Equal("<password>"))
+		Expect(AsString(bucket, "DB_USER")).To(Equal("<env-user>"))
+
+		os.Unsetenv("CREDENTIALS_DIRECTORY")
+
+		bucket = Layered(Environment(), SystemdCredentials())
+		Expect(AsString(bucket, "DB_PASSWORD")).To(Equal("<env-password>"))
+	})
+})
@@ -0,0 +1,324 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TLSOption is an option that changes the behavior of AsTLSConfig().
+type TLSOption func(*tlsOptions)
+
+// tlsOptions is the set of options applied by AsTLSConfig().
+type tlsOptions struct {
+	requireCert bool
+	minVersion  string
+}
+
+// RequireTLSCertificate returns an option that requires the certificate and
+// private key to be defined.
+//
+// It is typically used when configuring a TLS server.
+func RequireTLSCertificate() TLSOption {
+	return func(o *tlsOptions) {
+		o.requireCert = true
+	}
+}
+
+// TLSMinVersionDefault returns an option that sets the minimum TLS version to
+// use when the MIN_VERSION key is undefined.
+//
+// v must be one of "1.0", "1.1", "1.2" or "1.3". The default is "1.2".
+func TLSMinVersionDefault(v string) TLSOption {
+	return func(o *tlsOptions) {
+		o.minVersion = v
+	}
+}
+
+// tlsVersions is a map of the values accepted by the MIN_VERSION key to the
+// TLS version they represent.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsClientAuth is the enumeration of values accepted by the CLIENT_AUTH key.
+var tlsClientAuth = Enum{
+	Values: []string{"none", "request", "require", "verify-if-given", "require-and-verify"},
+}
+
+// tlsClientAuthTypes is a map of the values accepted by the CLIENT_AUTH key to
+// the client authentication policy they represent.
+var tlsClientAuthTypes = map[string]tls.ClientAuthType{
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify-if-given":    tls.VerifyClientCertIfGiven,
+	"require-and-verify": tls.RequireAndVerifyClientCert,
+}
+
+// pemPlaceholder is used in place of the content of PEM-encoded values in
+// errors, so that private keys are not disclosed.
+const pemPlaceholder = "<PEM data>"
+
+// AsTLSConfig returns a TLS configuration assembled from the values associated
+// with the keys that begin with prefix.
+//
+// The following keys are used, each of which is prefixed by prefix:
+//
+// ● CERT, the PEM-encoded certificate chain, beginning with the leaf
+// certificate
+//
+// ● KEY, the PEM-encoded private key for the leaf certificate
+//
+// ● CA, the PEM-encoded CA certificates used to verify peer certificates
+//
+// ● CLIENT_AUTH, the policy for client authentication, one of "none",
+// "request", "require", "verify-if-given" or "require-and-verify"
+//
+// ● MIN_VERSION, the minimum TLS version, one of "1.0", "1.1", "1.2" or "1.3"
+//
+// ● SERVER_NAME, the host name used to verify the server's certificate
+//
+// All keys are optional unless the RequireTLSCertificate() option is used, in
+// which case CERT and KEY are required. CERT and KEY must always be specified
+// together. The values may be specified using any data-source.
+//
+// It verifies that the private key matches the certificate and that none of
+// the certificates have expired. If there are any problems, it returns a
+// KeyErrors value describing all of them.
+func AsTLSConfig(b Bucket, prefix string, opts ...TLSOption) (*tls.Config, error) {
+	o := tlsOptions{
+		minVersion: "1.2",
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	b = Sub(b, prefix)
+
+	var errs KeyErrors
+
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err.(KeyError))
+		}
+	}
+
+	cfg := &tls.Config{}
+
+	minVersion, err := TryAsEnumDefault(b, "MIN_VERSION", o.minVersion, "1.0", "1.1", "1.2", "1.3")
+	collect(err)
+	cfg.MinVersion = tlsVersions[minVersion]
+
+	// CLIENT_AUTH is read once, as per TryAsEnumDefault(), such that its
+	// source is available if it requires CA to be defined.
+	clientAuthAccessor := accessor{"TryAsEnumDefault", false}
+	annotateDefault(b, "CLIENT_AUTH", clientAuthAccessor, "none")
+	clientAuthX := access(b, "CLIENT_AUTH", clientAuthAccessor)
+
+	clientAuth, err := try(func() string {
+		if v, ok := tlsClientAuth.parse(b, "CLIENT_AUTH", clientAuthX); ok {
+			return v
+		}
+		return "none"
+	})
+	collect(err)
+	cfg.ClientAuth = tlsClientAuthTypes[clientAuth]
+
+	cfg.ServerName, err = TryAsStringDefault(b, "SERVER_NAME", "")
+	collect(err)
+
+	if cert, ok, err := tlsCertificate(b, o); err != nil {
+		errs = append(errs, err...)
+	} else if ok {
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if pool, ok, err := tlsCertPool(b, "CA"); err != nil {
+		errs = append(errs, err...)
+	} else if ok {
+		cfg.RootCAs = pool
+		cfg.ClientCAs = pool
+	} else if cfg.ClientAuth >= tls.VerifyClientCertIfGiven {
+		errs = append(errs, InvalidValue{
+			Key:   qualify(b, "CLIENT_AUTH"),
+			Value: clientAuth,
+			Explanation: fmt.Sprintf(
+				`verifying client certificates requires %s to be defined`,
+				qualify(b, "CA"),
+			),
+			Source: clientAuthX.Source(),
+		})
+	}
+
+	if len(errs) != 0 {
+		return nil, errs
+	}
+
+	return cfg, nil
+}
+
+// tlsCertificate returns the certificate and private key described by the
+// CERT and KEY keys.
+//
+// ok is false if neither key is defined.
+func tlsCertificate(b Bucket, o tlsOptions) (_ tls.Certificate, ok bool, _ KeyErrors) {
+	certX, keyX := tlsValue(b, "CERT"), tlsValue(b, "KEY")
+
+	if certX.IsZero() && keyX.IsZero() && !o.requireCert {
+		return tls.Certificate{}, false, nil
+	}
+
+	var errs KeyErrors
+
+	certPEM, err := tlsPEM(b, "CERT", certX)
+	if err != nil {
+		errs = append(errs, err.(KeyError))
+	}
+
+	keyPEM, err := tlsPEM(b, "KEY", keyX)
+	if err != nil {
+		errs = append(errs, err.(KeyError))
+	}
+
+	if len(errs) != 0 {
+		return tls.Certificate{}, false, errs
+	}
+
+	if _, err := parseTLSCertificates(b, "CERT", certX, certPEM); err != nil {
+		return tls.Certificate{}, false, KeyErrors{err}
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, false, KeyErrors{
+			InvalidValue{
+				Key:   qualify(b, "KEY"),
+				Value: pemPlaceholder,
+				Explanation: fmt.Sprintf(
+					`expected a PEM-encoded private key that matches %s (%s)`,
+					qualify(b, "CERT"),
+					strings.TrimPrefix(err.Error(), "tls: "),
+				),
+				Source: keyX.Source(),
+			},
+		}
+	}
+
+	return cert, true, nil
+}
+
+// tlsCertPool returns a certificate pool containing the certificates
+// associated with k.
+//
+// ok is false if k is undefined.
+func tlsCertPool(b Bucket, k string) (_ *x509.CertPool, ok bool, _ KeyErrors) {
+	x := tlsValue(b, k)
+	if x.IsZero() {
+		return nil, false, nil
+	}
+
+	data, err := tlsPEM(b, k, x)
+	if err != nil {
+		return nil, false, KeyErrors{err.(KeyError)}
+	}
+
+	certs, kerr := parseTLSCertificates(b, k, x, data)
+	if kerr != nil {
+		return nil, false, KeyErrors{kerr}
+	}
+
+	pool := x509.NewCertPool()
+	for _, c := range certs {
+		pool.AddCert(c)
+	}
+
+	return pool, true, nil
+}
+
+// tlsValue returns the value associated with the PEM-encoded key k.
+//
+// The access is recorded as per TryAsBytes().
+func tlsValue(b Bucket, k string) Value {
+	return access(b, k, accessor{"TryAsBytes", false})
+}
+
+// tlsPEM returns the content of x, the PEM-encoded value associated with k.
+//
+// It returns an error if x is the zero-value or can not be read.
+func tlsPEM(b Bucket, k string, x Value) ([]byte, error) {
+	return try(func() []byte {
+		if x.IsZero() {
+			panic(notDefined(b, k))
+		}
+
+		return mustAsBytes(qualify(b, k), x)
+	})
+}
+
+// parseTLSCertificates parses the PEM-encoded certificates in data, the
+// content of x, which is the value associated with k. It verifies that the
+// certificates are currently valid.
+func parseTLSCertificates(b Bucket, k string, x Value, data []byte) ([]*x509.Certificate, KeyError) {
+	invalid := func(f string, v ...interface{}) KeyError {
+		return InvalidValue{
+			Key:         qualify(b, k),
+			Value:       pemPlaceholder,
+			Explanation: fmt.Sprintf(f, v...),
+			Source:      x.Source(),
+		}
+	}
+
+	var certs []*x509.Certificate
+	now := time.Now()
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, invalid(`certificate %d is malformed (%s)`, len(certs), err)
+		}
+
+		if now.After(c.NotAfter) {
+			return nil, invalid(
+				`certificate %d (%s) expired at %s`,
+				len(certs),
+				c.Subject,
+				c.NotAfter.Format(time.RFC3339),
+			)
+		}
+
+		if now.Before(c.NotBefore) {
+			return nil, invalid(
+				`certificate %d (%s) is not valid until %s`,
+				len(certs),
+				c.Subject,
+				c.NotBefore.Format(time.RFC3339),
+			)
+		}
+
+		certs = append(certs, c)
+	}
+
+	if len(certs) == 0 {
+		return nil, invalid(`expected at least one PEM-encoded certificate`)
+	}
+
+	return certs, nil
+}
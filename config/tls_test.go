@@ -0,0 +1,184 @@
+package config_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// generateCertificate returns a PEM-encoded self-signed certificate and its
+// private key, valid between the given times.
+func generateCertificate(cn string, notBefore, notAfter time.Time) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+var _ = Describe("func AsTLSConfig()", func() {
+	var certPEM, keyPEM []byte
+
+	BeforeEach(func() {
+		certPEM, keyPEM = generateCertificate(
+			"<cn>",
+			time.Now().Add(-time.Hour),
+			time.Now().Add(time.Hour),
+		)
+	})
+
+	It("returns a configuration with default settings if no keys are defined", func() {
+		cfg, err := AsTLSConfig(Map{}, "TLS_")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.Certificates).To(BeEmpty())
+		Expect(cfg.RootCAs).To(BeNil())
+		Expect(cfg.MinVersion).To(BeNumerically("==", tls.VersionTLS12))
+		Expect(cfg.ClientAuth).To(Equal(tls.NoClientCert))
+	})
+
+	It("populates the configuration from the prefixed keys", func() {
+		b := Map{
+			"TLS_CERT":        Bytes(certPEM),
+			"TLS_KEY":         String(string(keyPEM)),
+			"TLS_CA":          Bytes(certPEM),
+			"TLS_CLIENT_AUTH": String("require-and-verify"),
+			"TLS_MIN_VERSION": String("1.3"),
+			"TLS_SERVER_NAME": String("example.org"),
+		}
+
+		cfg, err := AsTLSConfig(b, "TLS_")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.Certificates).To(HaveLen(1))
+		Expect(cfg.RootCAs).NotTo(BeNil())
+		Expect(cfg.ClientCAs).To(BeIdenticalTo(cfg.RootCAs))
+		Expect(cfg.ClientAuth).To(Equal(tls.RequireAndVerifyClientCert))
+		Expect(cfg.MinVersion).To(BeNumerically("==", tls.VersionTLS13))
+		Expect(cfg.ServerName).To(Equal("example.org"))
+	})
+
+	It("uses the default minimum version specified by the option", func() {
+		cfg, err := AsTLSConfig(Map{}, "TLS_", TLSMinVersionDefault("1.3"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cfg.MinVersion).To(BeNumerically("==", tls.VersionTLS13))
+	})
+
+	It("requires the certificate and key if the option is used", func() {
+		_, err := AsTLSConfig(Map{}, "TLS_", RequireTLSCertificate())
+		Expect(err).To(Equal(KeyErrors{
+			NotDefined{Key: "TLS_CERT"},
+			NotDefined{Key: "TLS_KEY"},
+		}))
+	})
+
+	It("requires the key if the certificate is defined", func() {
+		b := Map{"TLS_CERT": Bytes(certPEM)}
+
+		_, err := AsTLSConfig(b, "TLS_")
+		Expect(err).To(Equal(KeyErrors{
+			NotDefined{Key: "TLS_KEY"},
+		}))
+	})
+
+	It("returns an error if the key does not match the certificate", func() {
+		_, otherKey := generateCertificate(
+			"<other>",
+			time.Now().Add(-time.Hour),
+			time.Now().Add(time.Hour),
+		)
+
+		b := Map{
+			"TLS_CERT": Bytes(certPEM),
+			"TLS_KEY":  Bytes(otherKey),
+		}
+
+		_, err := AsTLSConfig(b, "TLS_")
+		Expect(err).To(Equal(KeyErrors{
+			InvalidValue{
+				Key:         "TLS_KEY",
+				Value:       "<PEM data>",
+				Explanation: `expected a PEM-encoded private key that matches TLS_CERT (private key does not match public key)`,
+			},
+		}))
+	})
+
+	It("returns an error if a certificate has expired", func() {
+		notAfter := time.Now().Add(-time.Hour).Truncate(time.Second)
+		expired, _ := generateCertificate("expired.example.org", notAfter.Add(-time.Hour), notAfter)
+
+		b := Map{"TLS_CA": Bytes(expired)}
+
+		_, err := AsTLSConfig(b, "TLS_")
+		Expect(err).To(Equal(KeyErrors{
+			InvalidValue{
+				Key:         "TLS_CA",
+				Value:       "<PEM data>",
+				Explanation: `certificate 0 (CN=expired.example.org) expired at ` + notAfter.UTC().Format(time.RFC3339),
+			},
+		}))
+	})
+
+	It("returns an error if a value contains no certificates", func() {
+		b := Map{"TLS_CA": String("<invalid>")}
+
+		_, err := AsTLSConfig(b, "TLS_")
+		Expect(err).To(Equal(KeyErrors{
+			InvalidValue{
+				Key:         "TLS_CA",
+				Value:       "<PEM data>",
+				Explanation: `expected at least one PEM-encoded certificate`,
+			},
+		}))
+	})
+
+	It("returns all errors at once", func() {
+		b := Map{
+			"TLS_CLIENT_AUTH": String("verify-if-given"),
+			"TLS_MIN_VERSION": String("2.0"),
+		}
+
+		_, err := AsTLSConfig(b, "TLS_")
+		Expect(err).To(Equal(KeyErrors{
+			InvalidValue{
+				Key:         "TLS_MIN_VERSION",
+				Value:       "2.0",
+				Explanation: `expected one of "1.0", "1.1", "1.2" or "1.3"`,
+			},
+			InvalidValue{
+				Key:         "TLS_CLIENT_AUTH",
+				Value:       "verify-if-given",
+				Explanation: `verifying client certificates requires TLS_CA to be defined`,
+			},
+		}))
+	})
+})
@@ -0,0 +1,825 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TOMLFile returns a Bucket that produces configuration values from the TOML
+// document at path p.
+//
+// Tables are mapped to key prefixes, such that the "host" key within the
+// "[database]" table is mapped to the DATABASE_HOST bucket key. See JSONFile()
+// for a description of how nested values and arrays are mapped to keys.
+//
+// Integers are represented in decimal and underscores are removed from
+// numbers, such that they can be parsed by AsInt() and the other numeric
+// functions. Date-times are represented in RFC 3339 format.
+//
+// It returns a SyntaxError if the document is not well-formed, or if two keys
+// in the document map to the same bucket key.
+func TOMLFile(p string, opts ...DocumentOption) (Bucket, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseTOML(p, f, opts)
+}
+
+// TOMLReader returns a Bucket that produces configuration values from the TOML
+// document in r.
+//
+// See TOMLFile() for a description of how the document is mapped to keys.
+func TOMLReader(r io.Reader, opts ...DocumentOption) (Bucket, error) {
+	return parseTOML("", r, opts)
+}
+
+// parseTOML parses the TOML document in r and returns a bucket containing its
+// values.
+//
+// p is the path to the file, used only for error reporting.
+func parseTOML(p string, r io.Reader, opts []DocumentOption) (Bucket, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := (&tomlParser{
+		path:   p,
+		data:   strings.ReplaceAll(string(buf), "\r\n", "\n"),
+		line:   1,
+		tables: map[string]tomlTable{},
+		arrays: map[string]struct{}{},
+	}).parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return flattenDocument(p, root, opts)
+}
+
+// tomlParser parses the content of a TOML document.
+type tomlParser struct {
+	path string
+	data string
+	pos  int
+	line int
+
+	root      docNode
+	current   *docNode
+	currentID string
+
+	// tables records how each table was created. arrays is the set of arrays
+	// that have been defined by an array-of-tables header. They are keyed by
+	// tomlPath().
+	tables map[string]tomlTable
+	arrays map[string]struct{}
+
+	// inlines is the number of inline tables parsed so far. It is used to
+	// give the members of each inline table a distinct tomlPath().
+	inlines int
+}
+
+// tomlTable is an enumeration of the ways in which a TOML table is created,
+// which determines how it may be extended later in the document.
+type tomlTable int
+
+const (
+	// tomlImplicitTable is a table that is created implicitly by a header for
+	// one of its sub-tables. It may be defined later by its own header.
+	tomlImplicitTable tomlTable = iota
+
+	// tomlHeaderTable is a table that is defined by a header. It may not be
+	// defined again.
+	tomlHeaderTable
+
+	// tomlDottedTable is a table that is created by a dotted key. It may be
+	// extended by other dotted keys within the same table, but may not be
+	// defined by a header.
+	tomlDottedTable
+
+	// tomlInlineTable is a table that is defined by an inline table value. It
+	// may not be extended at all.
+	tomlInlineTable
+)
+
+// parse returns the root node of the document.
+func (p *tomlParser) parse() (docNode, error) {
+	p.root = docNode{Line: 1, Kind: docObject}
+	p.current = &p.root
+
+	for {
+		p.skipBlank()
+
+		if p.eof() {
+			return p.root, nil
+		}
+
+		var err error
+
+		switch p.peek() {
+		case '#':
+			p.skipLine()
+			continue
+		case '[':
+			err = p.parseHeader()
+		default:
+			err = p.parseKeyValue(p.current, p.currentID)
+		}
+
+		if err != nil {
+			return docNode{}, err
+		}
+
+		if err := p.parseEndOfLine(); err != nil {
+			return docNode{}, err
+		}
+	}
+}
+
+// parseHeader parses a table or array-of-tables header.
+func (p *tomlParser) parseHeader() error {
+	line := p.line
+	p.pos++ // opening bracket
+
+	isArray := p.peek() == '['
+	if isArray {
+		p.pos++
+	}
+
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	if isArray {
+		if !strings.HasPrefix(p.data[p.pos:], "]]") {
+			return p.errorf("expected ']]' after array of tables name")
+		}
+		p.pos += 2
+	} else {
+		if p.peek() != ']' {
+			return p.errorf("expected ']' after table name")
+		}
+		p.pos++
+	}
+
+	n := &p.root
+	id := ""
+
+	for i, k := range keys {
+		id = tomlPath(id, k)
+		last := i == len(keys)-1
+
+		m := findMember(n, k)
+
+		if m != nil && p.tables[id] == tomlInlineTable && m.Kind == docObject {
+			return p.errorAt(line, "%s is an inline table, which can not be extended", strings.Join(keys[:i+1], "."))
+		}
+
+		if m == nil {
+			var x docNode
+			if last && isArray {
+				x = docNode{
+					Line:     line,
+					Kind:     docArray,
+					Elements: []docNode{{Line: line, Kind: docObject}},
+				}
+				p.arrays[id] = struct{}{}
+			} else {
+				x = docNode{Line: line, Kind: docObject}
+			}
+
+			n.Members = append(n.Members, docMember{k, x})
+			m = &n.Members[len(n.Members)-1].Value
+		} else if last && isArray {
+			if _, ok := p.arrays[id]; !ok {
+				return p.errorAt(line, "%s is already defined", strings.Join(keys, "."))
+			}
+
+			m.Elements = append(m.Elements, docNode{Line: line, Kind: docObject})
+		} else if last {
+			if m.Kind != docObject {
+				return p.errorAt(line, "%s is already defined", strings.Join(keys, "."))
+			}
+
+			switch p.tables[id] {
+			case tomlHeaderTable:
+				return p.errorAt(line, "%s is already defined", strings.Join(keys, "."))
+			case tomlDottedTable:
+				return p.errorAt(line, "%s is already defined by a dotted key", strings.Join(keys, "."))
+			}
+		}
+
+		if m.Kind == docArray {
+			if _, ok := p.arrays[id]; !ok {
+				return p.errorAt(line, "%s is already defined", strings.Join(keys[:i+1], "."))
+			}
+
+			id += "#" + strconv.Itoa(len(m.Elements)-1)
+			m = &m.Elements[len(m.Elements)-1]
+		} else if m.Kind != docObject {
+			return p.errorAt(line, "%s is already defined", strings.Join(keys[:i+1], "."))
+		}
+
+		n = m
+	}
+
+	if !isArray {
+		p.tables[id] = tomlHeaderTable
+	}
+
+	p.current = n
+	p.currentID = id
+
+	return nil
+}
+
+// parseKeyValue parses a key/value pair and adds it to the table t, which is
+// identified by id.
+func (p *tomlParser) parseKeyValue(t *docNode, id string) error {
+	line := p.line
+
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after %s", strings.Join(keys, "."))
+	}
+
+	p.pos++
+	p.skipSpace()
+
+	v, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	for i, k := range keys[:len(keys)-1] {
+		id = tomlPath(id, k)
+		m := findMember(t, k)
+
+		if m == nil {
+			t.Members = append(t.Members, docMember{k, docNode{Line: line, Kind: docObject}})
+			m = &t.Members[len(t.Members)-1].Value
+			p.tables[id] = tomlDottedTable
+		} else if m.Kind != docObject {
+			return p.errorAt(line, "%s is already defined", strings.Join(keys[:i+1], "."))
+		} else {
+			switch p.tables[id] {
+			case tomlInlineTable:
+				return p.errorAt(line, "%s is an inline table, which can not be extended", strings.Join(keys[:i+1], "."))
+			case tomlImplicitTable, tomlHeaderTable:
+				return p.errorAt(line, "%s is already defined by a table header", strings.Join(keys[:i+1], "."))
+			}
+		}
+
+		t = m
+	}
+
+	k := keys[len(keys)-1]
+	id = tomlPath(id, k)
+
+	if findMember(t, k) != nil {
+		return p.errorAt(line, "%s is already defined", strings.Join(keys, "."))
+	}
+
+	if v.Kind == docObject {
+		p.tables[id] = tomlInlineTable
+	}
+
+	t.Members = append(t.Members, docMember{k, v})
+
+	return nil
+}
+
+// parseKey parses a (possibly dotted) key, and any trailing whitespace.
+func (p *tomlParser) parseKey() ([]string, error) {
+	var keys []string
+
+	for {
+		p.skipSpace()
+
+		var (
+			k   string
+			err error
+		)
+
+		switch p.peek() {
+		case '"':
+			k, err = p.parseBasicString()
+		case '\'':
+			k, err = p.parseLiteralString()
+		default:
+			k = p.parseBareKey()
+			if k == "" {
+				return nil, p.errorf("expected a key")
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, k)
+		p.skipSpace()
+
+		if p.peek() != '.' {
+			return keys, nil
+		}
+
+		p.pos++
+	}
+}
+
+// parseBareKey parses an unquoted key.
+func (p *tomlParser) parseBareKey() string {
+	start := p.pos
+
+	for !p.eof() {
+		c := p.peek()
+
+		if c == '_' || c == '-' ||
+			'a' <= c && c <= 'z' ||
+			'A' <= c && c <= 'Z' ||
+			'0' <= c && c <= '9' {
+			p.pos++
+			continue
+		}
+
+		break
+	}
+
+	return p.data[start:p.pos]
+}
+
+// parseValue parses a value.
+func (p *tomlParser) parseValue() (docNode, error) {
+	line := p.line
+
+	var (
+		s   string
+		err error
+	)
+
+	switch {
+	case strings.HasPrefix(p.data[p.pos:], `"""`):
+		s, err = p.parseMultiLineString(`"""`, true)
+	case strings.HasPrefix(p.data[p.pos:], `'''`):
+		s, err = p.parseMultiLineString(`'''`, false)
+	case p.peek() == '"':
+		s, err = p.parseBasicString()
+	case p.peek() == '\'':
+		s, err = p.parseLiteralString()
+	case p.peek() == '[':
+		return p.parseArray()
+	case p.peek() == '{':
+		return p.parseInlineTable()
+	default:
+		s, err = p.parseLiteralValue()
+	}
+
+	if err != nil {
+		return docNode{}, err
+	}
+
+	return docNode{Line: line, Kind: docScalar, Scalar: s}, nil
+}
+
+// parseArray parses an array value.
+func (p *tomlParser) parseArray() (docNode, error) {
+	line := p.line
+	p.pos++ // opening bracket
+
+	n := docNode{Line: line, Kind: docArray}
+
+	for {
+		p.skipBlankAndComments()
+
+		if p.peek() == ']' {
+			p.pos++
+			return n, nil
+		}
+
+		if p.eof() {
+			return docNode{}, p.errorAt(line, "unterminated array")
+		}
+
+		v, err := p.parseValue()
+		if err != nil {
+			return docNode{}, err
+		}
+
+		n.Elements = append(n.Elements, v)
+
+		p.skipBlankAndComments()
+
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return docNode{}, p.errorf("expected ',' or ']' after array element")
+		}
+	}
+}
+
+// parseInlineTable parses an inline table value.
+func (p *tomlParser) parseInlineTable() (docNode, error) {
+	p.pos++ // opening brace
+
+	n := docNode{Line: p.line, Kind: docObject}
+
+	p.inlines++
+	id := "\x01" + strconv.Itoa(p.inlines)
+
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return n, nil
+	}
+
+	for {
+		if err := p.parseKeyValue(&n, id); err != nil {
+			return docNode{}, err
+		}
+
+		p.skipSpace()
+
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return n, nil
+		default:
+			return docNode{}, p.errorf("expected ',' or '}' after inline table value")
+		}
+	}
+}
+
+// parseBasicString parses a single-line string enclosed in double quotes,
+// interpreting any escape sequences.
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++ // opening quote
+
+	var v strings.Builder
+
+	for !p.eof() {
+		c := p.peek()
+
+		switch c {
+		case '"':
+			p.pos++
+			return v.String(), nil
+		case '\n':
+			return "", p.errorf("unterminated string")
+		case '\\':
+			if err := p.parseEscape(&v); err != nil {
+				return "", err
+			}
+		default:
+			v.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+// parseLiteralString parses a single-line string enclosed in single quotes.
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++ // opening quote
+	start := p.pos
+
+	for !p.eof() {
+		switch p.peek() {
+		case '\'':
+			v := p.data[start:p.pos]
+			p.pos++
+			return v, nil
+		case '\n':
+			return "", p.errorf("unterminated string")
+		}
+
+		p.pos++
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+// parseMultiLineString parses a string enclosed in the given delimiter, which
+// may span multiple lines. Escape sequences are interpreted if escapes is
+// true.
+func (p *tomlParser) parseMultiLineString(delim string, escapes bool) (string, error) {
+	line := p.line
+	p.pos += len(delim)
+
+	// A newline immediately following the opening delimiter is trimmed.
+	if p.peek() == '\n' {
+		p.pos++
+		p.line++
+	}
+
+	var v strings.Builder
+
+	for !p.eof() {
+		if strings.HasPrefix(p.data[p.pos:], delim) {
+			// Up to two additional quotes are permitted immediately before
+			// the closing delimiter.
+			n := len(delim)
+			for n < 5 && p.pos+n < len(p.data) && p.data[p.pos+n] == delim[0] {
+				n++
+			}
+
+			v.WriteString(p.data[p.pos+len(delim) : p.pos+n])
+			p.pos += n
+
+			return v.String(), nil
+		}
+
+		c := p.peek()
+
+		if c == '\\' && escapes {
+			if p.isLineEndingBackslash() {
+				p.pos++
+				p.skipBlank()
+				continue
+			}
+
+			if err := p.parseEscape(&v); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		if c == '\n' {
+			p.line++
+		}
+
+		v.WriteByte(c)
+		p.pos++
+	}
+
+	return "", p.errorAt(line, "unterminated multi-line string")
+}
+
+// isLineEndingBackslash returns true if the backslash at the current position
+// is followed only by whitespace on the current line.
+func (p *tomlParser) isLineEndingBackslash() bool {
+	for i := p.pos + 1; i < len(p.data); i++ {
+		switch p.data[i] {
+		case ' ', '\t':
+		case '\n':
+			return true
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// parseEscape parses the escape sequence at the current position and writes
+// the character it represents to v.
+func (p *tomlParser) parseEscape(v *strings.Builder) error {
+	p.pos++ // backslash
+
+	if p.eof() {
+		return p.errorf("unterminated string")
+	}
+
+	e := p.peek()
+	p.pos++
+
+	switch e {
+	case 'b':
+		v.WriteByte('\b')
+	case 't':
+		v.WriteByte('\t')
+	case 'n':
+		v.WriteByte('\n')
+	case 'f':
+		v.WriteByte('\f')
+	case 'r':
+		v.WriteByte('\r')
+	case '"', '\\':
+		v.WriteByte(e)
+	case 'u', 'U':
+		n := 4
+		if e == 'U' {
+			n = 8
+		}
+
+		if p.pos+n > len(p.data) {
+			return p.errorf("invalid unicode escape sequence")
+		}
+
+		x, err := strconv.ParseUint(p.data[p.pos:p.pos+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(x)) {
+			return p.errorf("invalid unicode escape sequence \\%c%s", e, p.data[p.pos:p.pos+n])
+		}
+
+		p.pos += n
+		v.WriteRune(rune(x))
+	default:
+		return p.errorf("unrecognised escape sequence \\%c", e)
+	}
+
+	return nil
+}
+
+var (
+	// tomlDecimalPattern matches TOML decimal integers and floats.
+	tomlDecimalPattern = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+
+	// tomlPrefixedIntegerPattern matches TOML hexadecimal, octal and binary
+	// integers.
+	tomlPrefixedIntegerPattern = regexp.MustCompile(`^0(x[0-9A-Fa-f](_?[0-9A-Fa-f])*|o[0-7](_?[0-7])*|b[01](_?[01])*)$`)
+
+	// tomlSpecialFloatPattern matches the TOML infinity and NaN floats.
+	tomlSpecialFloatPattern = regexp.MustCompile(`^[+-]?(inf|nan)$`)
+
+	// tomlDateTimePattern matches TOML date-times, dates and times.
+	tomlDateTimePattern = regexp.MustCompile(`^([0-9]{4}-[0-9]{2}-[0-9]{2}([Tt ][0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?([Zz]|[+-][0-9]{2}:[0-9]{2})?)?|[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?)$`)
+
+	// tomlDatePattern matches a TOML date without a time component.
+	tomlDatePattern = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+)
+
+// parseLiteralValue parses a boolean, number or date-time value, returning its
+// string representation.
+func (p *tomlParser) parseLiteralValue() (string, error) {
+	start := p.pos
+
+	for !p.eof() && !strings.ContainsRune(" \t\n,]}#", rune(p.peek())) {
+		p.pos++
+	}
+
+	v := p.data[start:p.pos]
+
+	// A date may be separated from the time by a space.
+	if tomlDatePattern.MatchString(v) &&
+		p.peek() == ' ' &&
+		p.pos+1 < len(p.data) &&
+		'0' <= p.data[p.pos+1] && p.data[p.pos+1] <= '9' {
+		p.pos++
+		for !p.eof() && !strings.ContainsRune(" \t\n,]}#", rune(p.peek())) {
+			p.pos++
+		}
+
+		v = p.data[start:p.pos]
+	}
+
+	switch {
+	case v == "true" || v == "false":
+		return v, nil
+
+	case tomlDecimalPattern.MatchString(v):
+		v = strings.ReplaceAll(v, "_", "")
+		return strings.TrimPrefix(v, "+"), nil
+
+	case tomlPrefixedIntegerPattern.MatchString(v):
+		n, err := strconv.ParseInt(v, 0, 64)
+		if err != nil {
+			return "", p.errorf("integer %s is out of range", v)
+		}
+		return strconv.FormatInt(n, 10), nil
+
+	case tomlSpecialFloatPattern.MatchString(v):
+		return v, nil
+
+	case tomlDateTimePattern.MatchString(v):
+		if len(v) > 10 && v[10] == ' ' {
+			v = v[:10] + "T" + v[11:]
+		}
+		return v, nil
+
+	case v == "":
+		return "", p.errorf("expected a value")
+
+	default:
+		return "", p.errorf("invalid value %q", v)
+	}
+}
+
+// parseEndOfLine parses the remainder of a line after a key/value pair or a
+// header, which may only contain whitespace and a comment.
+func (p *tomlParser) parseEndOfLine() error {
+	p.skipSpace()
+
+	switch p.peek() {
+	case 0, '\n':
+	case '#':
+		p.skipLine()
+	default:
+		return p.errorf("unexpected %q at end of line", p.peek())
+	}
+
+	return nil
+}
+
+// skipBlankAndComments advances past any whitespace, including newlines, and
+// comments.
+func (p *tomlParser) skipBlankAndComments() {
+	for {
+		p.skipBlank()
+
+		if p.peek() != '#' {
+			return
+		}
+
+		p.skipLine()
+	}
+}
+
+// skipBlank advances past any whitespace, including newlines.
+func (p *tomlParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case '\n':
+			p.line++
+		case ' ', '\t', '\r':
+		default:
+			return
+		}
+
+		p.pos++
+	}
+}
+
+// skipSpace advances past any whitespace on the current line.
+func (p *tomlParser) skipSpace() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// skipLine advances to the end of the current line, without consuming the
+// newline itself.
+func (p *tomlParser) skipLine() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+// peek returns the byte at the current position, or zero at the end of the
+// content.
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.data[p.pos]
+}
+
+// eof returns true if the entire content has been consumed.
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+// errorf returns a SyntaxError for the current line.
+func (p *tomlParser) errorf(f string, v ...interface{}) error {
+	return p.errorAt(p.line, f, v...)
+}
+
+// errorAt returns a SyntaxError for a specific line.
+func (p *tomlParser) errorAt(line int, f string, v ...interface{}) error {
+	return SyntaxError{
+		Path:        p.path,
+		Line:        line,
+		Explanation: fmt.Sprintf(f, v...),
+	}
+}
+
+// tomlPath returns a string that uniquely identifies the key k within the
+// table identified by parent.
+func tomlPath(parent, k string) string {
+	return parent + "\x00" + k
+}
+
+// findMember returns the value of the member of n named k, or nil if there is
+// no such member.
+func findMember(n *docNode, k string) *docNode {
+	for i := range n.Members {
+		if n.Members[i].Name == k {
+			return &n.Members[i].Value
+		}
+	}
+
+	return nil
+}
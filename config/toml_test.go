@@ -0,0 +1,131 @@
+package config_test
+
+import (
+	"strings"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func TOMLFile()", func() {
+	It("returns a bucket containing the values in the file", func() {
+		b, err := TOMLFile("./testdata/example.toml")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(b.Get("TITLE").String()).To(Equal("example"))
+		Expect(b.Get("DATABASE_HOST").String()).To(Equal("db.example.org"))
+		Expect(AsInt(b, "DATABASE_PORT")).To(Equal(5432))
+		Expect(b.Get("SERVERS_0_HOST").String()).To(Equal("a.example.org"))
+		Expect(b.Get("SERVERS_1_HOST").String()).To(Equal("b.example.org"))
+		Expect(b.Get("DATABASE_TIMEOUT").Source()).To(Equal("line 7 of ./testdata/example.toml"))
+	})
+
+	It("returns an error if the file does not exist", func() {
+		_, err := TOMLFile("./testdata/does-not-exist.toml")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("includes the path in syntax errors", func() {
+		_, err := TOMLFile("./testdata/example.env")
+		Expect(err).To(MatchError(
+			`syntax error in ./testdata/example.env on line 2: invalid value "<foo>"`,
+		))
+	})
+})
+
+var _ = Describe("func TOMLReader()", func() {
+	parse := func(s string, opts ...DocumentOption) Bucket {
+		b, err := TOMLReader(strings.NewReader(s), opts...)
+		Expect(err).ShouldNot(HaveOccurred())
+		return b
+	}
+
+	DescribeTable(
+		"it maps values to keys",
+		func(content, key, expect string) {
+			b := parse(content)
+			Expect(b.Get(key).String()).To(Equal(expect))
+		},
+		Entry("basic string", `key = "value"`, "KEY", "value"),
+		Entry("basic string with escapes", `key = "a\tb\"c\\d\u00e9\U0001F600"`, "KEY", "a\tb\"c\\dé😀"),
+		Entry("literal string", `key = 'C:\path'`, "KEY", `C:\path`),
+		Entry("multi-line basic string", "key = \"\"\"\na\nb\"\"\"", "KEY", "a\nb"),
+		Entry("multi-line basic string with line continuation", "key = \"\"\"\na \\\n    b\"\"\"", "KEY", "a b"),
+		Entry("multi-line basic string with trailing quotes", `key = """a"""""`, "KEY", `a""`),
+		Entry("multi-line literal string", "key = '''\na\\n\nb'''", "KEY", "a\\n\nb"),
+		Entry("integer", "key = +1_000", "KEY", "1000"),
+		Entry("negative integer", "key = -17", "KEY", "-17"),
+		Entry("hexadecimal integer", "key = 0xdead_beef", "KEY", "3735928559"),
+		Entry("octal integer", "key = 0o755", "KEY", "493"),
+		Entry("binary integer", "key = 0b1101", "KEY", "13"),
+		Entry("float", "key = 6.626e-34", "KEY", "6.626e-34"),
+		Entry("infinite float", "key = -inf", "KEY", "-inf"),
+		Entry("boolean", "key = true", "KEY", "true"),
+		Entry("offset date-time", "key = 1979-05-27T07:32:00Z", "KEY", "1979-05-27T07:32:00Z"),
+		Entry("date-time separated by a space", "key = 1979-05-27 07:32:00-07:00", "KEY", "1979-05-27T07:32:00-07:00"),
+		Entry("local date", "key = 1979-05-27", "KEY", "1979-05-27"),
+		Entry("local time", "key = 07:32:00.999", "KEY", "07:32:00.999"),
+		Entry("quoted key", `"http.timeout" = "5s"`, "HTTP_TIMEOUT", "5s"),
+		Entry("dotted key", `http.timeout = "5s"`, "HTTP_TIMEOUT", "5s"),
+		Entry("table", "[database]\nhost = \"db\"", "DATABASE_HOST", "db"),
+		Entry("nested table", "[a.b]\nc = 1", "A_B_C", "1"),
+		Entry("implicit table defined later", "[a.b]\nc = 1\n[a]\nd = 2", "A_D", "2"),
+		Entry("inline table", `http = { timeout = "5s", tls.enabled = true }`, "HTTP_TLS_ENABLED", "true"),
+		Entry("dotted keys extending a table created by dotted keys", "a.b.c = 1\na.b.d = 2", "A_B_D", "2"),
+		Entry("sub-table of a table created by dotted keys", "[a]\nb.c = 1\n[a.b.d]\ne = 2", "A_B_D_E", "2"),
+		Entry("array", `key = ["a", 'b', 3]`, "KEY", "a,b,3"),
+		Entry("multi-line array", "key = [\n  1, # one\n  2,\n]", "KEY", "1,2"),
+		Entry("array of inline tables", `servers = [{ host = "a" }, { host = "b" }]`, "SERVERS_1_HOST", "b"),
+		Entry("array of tables", "[[servers]]\nhost = \"a\"\n[[servers]]\nhost = \"b\"", "SERVERS_1_HOST", "b"),
+		Entry("sub-table of array of tables", "[[servers]]\n[servers.tls]\nenabled = true", "SERVERS_0_TLS_ENABLED", "true"),
+		Entry("comment after value", "key = 1 # comment", "KEY", "1"),
+	)
+
+	It("treats empty strings and empty arrays as undefined", func() {
+		b := parse("a = ''\nb = []")
+
+		var v Value
+		v = b.Get("A")
+		Expect(v.IsZero()).To(BeTrue())
+		v = b.Get("B")
+		Expect(v.IsZero()).To(BeTrue())
+	})
+
+	It("supports a custom key separator and case", func() {
+		b := parse(
+			"[http]\ntimeout = \"5s\"",
+			DocumentKeySeparator("."),
+			DocumentKeyCase(strings.ToLower),
+		)
+		Expect(b.Get("http.timeout").String()).To(Equal("5s"))
+	})
+
+	DescribeTable(
+		"it returns a syntax error",
+		func(content, expect string) {
+			_, err := TOMLReader(strings.NewReader(content))
+			Expect(err).To(MatchError(expect))
+		},
+		Entry("missing equals", "a = 1\nkey value", `syntax error on line 2: expected '=' after key`),
+		Entry("missing key", "\n= 1", `syntax error on line 2: expected a key`),
+		Entry("missing value", "\nkey = \n", `syntax error on line 2: expected a value`),
+		Entry("invalid value", "key = yes", `syntax error on line 1: invalid value "yes"`),
+		Entry("leading zero", "key = 012", `syntax error on line 1: invalid value "012"`),
+		Entry("unterminated string", "key = \"value\nother = 1", `syntax error on line 1: unterminated string`),
+		Entry("unterminated multi-line string", "\nkey = '''\n\n", `syntax error on line 2: unterminated multi-line string`),
+		Entry("unterminated array", "\nkey = [1,\n\n", `syntax error on line 2: unterminated array`),
+		Entry("unrecognised escape sequence", `key = "\q"`, `syntax error on line 1: unrecognised escape sequence \q`),
+		Entry("content after value", `key = "a" "b"`, `syntax error on line 1: unexpected '"' at end of line`),
+		Entry("duplicate key", "a = 1\na = 2", `syntax error on line 2: a is already defined`),
+		Entry("duplicate table", "[a]\n[b]\n[a]", `syntax error on line 3: a is already defined`),
+		Entry("table redefining a value", "a = 1\n[a]", `syntax error on line 2: a is already defined`),
+		Entry("table reopening an inline table", "a = { x = 1 }\n[a]", `syntax error on line 2: a is an inline table, which can not be extended`),
+		Entry("sub-table of an inline table", "a = { x = 1 }\n[a.b]", `syntax error on line 2: a is an inline table, which can not be extended`),
+		Entry("dotted key extending an inline table", "a = { x = 1 }\na.y = 2", `syntax error on line 2: a is an inline table, which can not be extended`),
+		Entry("dotted key extending an inline table within an inline table", "a = { b = { c = 1 }, b.d = 2 }", `syntax error on line 1: b is an inline table, which can not be extended`),
+		Entry("table defining a table created by dotted keys", "a.b = 1\n[a]", `syntax error on line 2: a is already defined by a dotted key`),
+		Entry("dotted key extending a table defined by a header", "[a.b]\nc = 1\n[a]\nb.d = 2", `syntax error on line 4: b is already defined by a table header`),
+		Entry("keys that map to the same bucket key", "http_timeout = 1\n[http]\ntimeout = 2", `syntax error on line 3: HTTP_TIMEOUT is already defined on line 1`),
+	)
+})
@@ -0,0 +1,20 @@
+package config
+
+// try calls fn and returns its result.
+//
+// If fn panics with a KeyError, the error is returned instead. Any other panic
+// is propagated to the caller.
+func try[T any](fn func() T) (v T, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			e, ok := p.(KeyError)
+			if !ok {
+				panic(p)
+			}
+
+			err = e
+		}
+	}()
+
+	return fn(), nil
+}
@@ -0,0 +1,517 @@
+package config_test
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("error-returning accessors", func() {
+	DescribeTable(
+		"it returns the value",
+		func(fn func(Bucket) (interface{}, error), value string, expect interface{}, _ string, _ bool) {
+			b := Map{"<key>": String(value)}
+
+			v, err := fn(b)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(v).To(Equal(expect))
+		},
+		tryTableEntries...,
+	)
+
+	DescribeTable(
+		"it returns an InvalidValue error if the value is invalid",
+		func(fn func(Bucket) (interface{}, error), _ string, _ interface{}, invalid string, _ bool) {
+			if invalid == "" {
+				Skip("all values are valid")
+			}
+
+			b := Map{"<key>": String(invalid)}
+
+			_, err := fn(b)
+
+			var target InvalidValue
+			Expect(errors.As(err, &target)).To(BeTrue())
+			Expect(target.Key).To(Equal("<key>"))
+			Expect(target.Value).To(Equal(invalid))
+		},
+		tryTableEntries...,
+	)
+
+	DescribeTable(
+		"it returns a NotDefined error if a required key is undefined",
+		func(fn func(Bucket) (interface{}, error), _ string, _ interface{}, _ string, required bool) {
+			_, err := fn(Map{})
+
+			if required {
+				Expect(err).To(Equal(NotDefined{Key: "<key>"}))
+			} else {
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+		},
+		tryTableEntries...,
+	)
+
+	It("returns an Unreadable error if the value can not be read", func() {
+		b := Map{"<key>": File("./testdata/does-not-exist")}
+
+		_, err := TryAsString(b, "<key>")
+
+		var target Unreadable
+		Expect(errors.As(err, &target)).To(BeTrue())
+		Expect(target.Key).To(Equal("<key>"))
+		Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+	})
+})
+
+func mustParseURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return u
+}
+
+var tryTableEntries = []TableEntry{
+	Entry(
+		"func TryAsBool()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsBool(b, "<key>")
+		},
+		"yes", true, "<invalid>", true,
+	),
+	Entry(
+		"func TryAsBoolT()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsBoolT(b, "<key>")
+		},
+		"yes", true, "<invalid>", false,
+	),
+	Entry(
+		"func TryAsBoolF()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsBoolF(b, "<key>")
+		},
+		"yes", true, "<invalid>", false,
+	),
+	Entry(
+		"func TryAsBoolDefault()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsBoolDefault(b, "<key>", false)
+		},
+		"yes", true, "<invalid>", false,
+	),
+	Entry(
+		"func TryAsBytes()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsBytes(b, "<key>")
+		},
+		"<value>", []byte("<value>"), "", true,
+	),
+	Entry(
+		"func TryAsBytesDefault()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsBytesDefault(b, "<key>", []byte("<default>"))
+		},
+		"<value>", []byte("<value>"), "", false,
+	),
+	Entry(
+		"func TryAsDuration()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsDuration(b, "<key>")
+		},
+		"7s", 7*time.Second, "<invalid>", true,
+	),
+	Entry(
+		"func TryAsDurationDefault()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsDurationDefault(b, "<key>", 5*time.Second)
+		},
+		"7s", 7*time.Second, "<invalid>", false,
+	),
+	Entry(
+		"func TryAsDurationBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsDurationBetween(b, "<key>", 1*time.Second, 10*time.Second)
+		},
+		"7s", 7*time.Second, "<invalid>", true,
+	),
+	Entry(
+		"func TryAsDurationDefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsDurationDefaultBetween(b, "<key>", 5*time.Second, 1*time.Second, 10*time.Second)
+		},
+		"7s", 7*time.Second, "<invalid>", false,
+	),
+	Entry(
+		"func TryAsFloat32()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsFloat32(b, "<key>")
+		},
+		"7", float32(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsFloat32Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsFloat32Default(b, "<key>", 5)
+		},
+		"7", float32(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsFloat32Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsFloat32Between(b, "<key>", 1, 10)
+		},
+		"7", float32(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsFloat32DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsFloat32DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", float32(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsFloat64()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsFloat64(b, "<key>")
+		},
+		"7", float64(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsFloat64Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsFloat64Default(b, "<key>", 5)
+		},
+		"7", float64(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsFloat64Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsFloat64Between(b, "<key>", 1, 10)
+		},
+		"7", float64(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsFloat64DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsFloat64DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", float64(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt(b, "<key>")
+		},
+		"7", int(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsIntDefault()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsIntDefault(b, "<key>", 5)
+		},
+		"7", int(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsIntBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsIntBetween(b, "<key>", 1, 10)
+		},
+		"7", int(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsIntDefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsIntDefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", int(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt8()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt8(b, "<key>")
+		},
+		"7", int8(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsInt8Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt8Default(b, "<key>", 5)
+		},
+		"7", int8(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt8Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt8Between(b, "<key>", 1, 10)
+		},
+		"7", int8(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsInt8DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt8DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", int8(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt16()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt16(b, "<key>")
+		},
+		"7", int16(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsInt16Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt16Default(b, "<key>", 5)
+		},
+		"7", int16(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt16Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt16Between(b, "<key>", 1, 10)
+		},
+		"7", int16(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsInt16DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt16DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", int16(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt32()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt32(b, "<key>")
+		},
+		"7", int32(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsInt32Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt32Default(b, "<key>", 5)
+		},
+		"7", int32(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt32Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt32Between(b, "<key>", 1, 10)
+		},
+		"7", int32(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsInt32DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt32DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", int32(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt64()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt64(b, "<key>")
+		},
+		"7", int64(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsInt64Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt64Default(b, "<key>", 5)
+		},
+		"7", int64(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsInt64Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt64Between(b, "<key>", 1, 10)
+		},
+		"7", int64(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsInt64DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsInt64DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", int64(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsString()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsString(b, "<key>")
+		},
+		"<value>", "<value>", "", true,
+	),
+	Entry(
+		"func TryAsStringDefault()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsStringDefault(b, "<key>", "<default>")
+		},
+		"<value>", "<value>", "", false,
+	),
+	Entry(
+		"func TryAsUint()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint(b, "<key>")
+		},
+		"7", uint(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUintDefault()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUintDefault(b, "<key>", 5)
+		},
+		"7", uint(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUintBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUintBetween(b, "<key>", 1, 10)
+		},
+		"7", uint(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUintDefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUintDefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", uint(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUint8()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint8(b, "<key>")
+		},
+		"7", uint8(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUint8Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint8Default(b, "<key>", 5)
+		},
+		"7", uint8(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUint8Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint8Between(b, "<key>", 1, 10)
+		},
+		"7", uint8(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUint8DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint8DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", uint8(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUint16()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint16(b, "<key>")
+		},
+		"7", uint16(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUint16Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint16Default(b, "<key>", 5)
+		},
+		"7", uint16(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUint16Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint16Between(b, "<key>", 1, 10)
+		},
+		"7", uint16(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUint16DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint16DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", uint16(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUint32()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint32(b, "<key>")
+		},
+		"7", uint32(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUint32Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint32Default(b, "<key>", 5)
+		},
+		"7", uint32(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUint32Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint32Between(b, "<key>", 1, 10)
+		},
+		"7", uint32(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUint32DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint32DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", uint32(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUint64()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint64(b, "<key>")
+		},
+		"7", uint64(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUint64Default()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint64Default(b, "<key>", 5)
+		},
+		"7", uint64(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsUint64Between()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint64Between(b, "<key>", 1, 10)
+		},
+		"7", uint64(7), "<invalid>", true,
+	),
+	Entry(
+		"func TryAsUint64DefaultBetween()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsUint64DefaultBetween(b, "<key>", 5, 1, 10)
+		},
+		"7", uint64(7), "<invalid>", false,
+	),
+	Entry(
+		"func TryAsURL()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsURL(b, "<key>")
+		},
+		"http://example.org", mustParseURL("http://example.org"), ":", true,
+	),
+	Entry(
+		"func TryAsURLDefault()",
+		func(b Bucket) (interface{}, error) {
+			return TryAsURLDefault(b, "<key>", "http://localhost")
+		},
+		"http://example.org", mustParseURL("http://example.org"), ":", false,
+	),
+}
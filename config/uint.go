@@ -16,13 +16,31 @@ const (
 // AsUint returns the uint representation of the value associated with k or
 // panics if unable to do so.
 func AsUint(b Bucket, k string) uint {
-	return uint(asUint(b, k, 0, 0, MaxUint))
+	return uint(asUint(b, k, accessor{"AsUint", false}, 0, 0, MaxUint))
+}
+
+// TryAsUint returns the uint representation of the value associated with k, or
+// an error if unable to do so.
+func TryAsUint(b Bucket, k string) (uint, error) {
+	return try(func() uint {
+		return uint(asUint(b, k, accessor{"TryAsUint", false}, 0, 0, MaxUint))
+	})
 }
 
 // AsUintDefault returns the uint representation of the value associated with k,
 // or the default value v if k is undefined.
 func AsUintDefault(b Bucket, k string, v uint) uint {
-	return uint(asUintDefault(b, k, 0, uint64(v), 0, MaxUint))
+	return uint(asUintDefault(b, k, accessor{"AsUintDefault", false}, 0, uint64(v), 0, MaxUint))
+}
+
+// TryAsUintDefault returns the uint representation of the value associated with
+// k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsUintDefault(b Bucket, k string, v uint) (uint, error) {
+	return try(func() uint {
+		return uint(asUintDefault(b, k, accessor{"TryAsUintDefault", false}, 0, uint64(v), 0, MaxUint))
+	})
 }
 
 // AsUintBetween returns the uint representation of the value associated with k
@@ -30,7 +48,17 @@ func AsUintDefault(b Bucket, k string, v uint) uint {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUintBetween(b Bucket, k string, min, max int) uint {
-	return uint(asUint(b, k, 0, uint64(min), uint64(max)))
+	return uint(asUint(b, k, accessor{"AsUintBetween", true}, 0, uint64(min), uint64(max)))
+}
+
+// TryAsUintBetween returns the uint representation of the value associated with
+// k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUintBetween(b Bucket, k string, min, max int) (uint, error) {
+	return try(func() uint {
+		return uint(asUint(b, k, accessor{"TryAsUintBetween", true}, 0, uint64(min), uint64(max)))
+	})
 }
 
 // AsUintDefaultBetween returns the uint representation of the value associated
@@ -38,19 +66,47 @@ func AsUintBetween(b Bucket, k string, min, max int) uint {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUintDefaultBetween(b Bucket, k string, v, min, max int) uint {
-	return uint(asUintDefault(b, k, 0, uint64(v), uint64(min), uint64(max)))
+	return uint(asUintDefault(b, k, accessor{"AsUintDefaultBetween", true}, 0, uint64(v), uint64(min), uint64(max)))
+}
+
+// TryAsUintDefaultBetween returns the uint representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUintDefaultBetween(b Bucket, k string, v, min, max int) (uint, error) {
+	return try(func() uint {
+		return uint(asUintDefault(b, k, accessor{"TryAsUintDefaultBetween", true}, 0, uint64(v), uint64(min), uint64(max)))
+	})
 }
 
 // AsUint8 returns the uint8 representation of the value associated with k or
 // panics if unable to do so.
 func AsUint8(b Bucket, k string) uint8 {
-	return uint8(asUint(b, k, 8, 0, math.MaxUint8))
+	return uint8(asUint(b, k, accessor{"AsUint8", false}, 8, 0, math.MaxUint8))
+}
+
+// TryAsUint8 returns the uint8 representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsUint8(b Bucket, k string) (uint8, error) {
+	return try(func() uint8 {
+		return uint8(asUint(b, k, accessor{"TryAsUint8", false}, 8, 0, math.MaxUint8))
+	})
 }
 
 // AsUint8Default returns the uint8 representation of the value associated with
 // k, or the default value v if k is undefined.
 func AsUint8Default(b Bucket, k string, v uint8) uint8 {
-	return uint8(asUintDefault(b, k, 8, uint64(v), 0, math.MaxUint8))
+	return uint8(asUintDefault(b, k, accessor{"AsUint8Default", false}, 8, uint64(v), 0, math.MaxUint8))
+}
+
+// TryAsUint8Default returns the uint8 representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsUint8Default(b Bucket, k string, v uint8) (uint8, error) {
+	return try(func() uint8 {
+		return uint8(asUintDefault(b, k, accessor{"TryAsUint8Default", false}, 8, uint64(v), 0, math.MaxUint8))
+	})
 }
 
 // AsUint8Between returns the uint8 representation of the value associated with
@@ -58,7 +114,17 @@ func AsUint8Default(b Bucket, k string, v uint8) uint8 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUint8Between(b Bucket, k string, min, max int8) uint8 {
-	return uint8(asUint(b, k, 8, uint64(min), uint64(max)))
+	return uint8(asUint(b, k, accessor{"AsUint8Between", true}, 8, uint64(min), uint64(max)))
+}
+
+// TryAsUint8Between returns the uint8 representation of the value associated
+// with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUint8Between(b Bucket, k string, min, max int8) (uint8, error) {
+	return try(func() uint8 {
+		return uint8(asUint(b, k, accessor{"TryAsUint8Between", true}, 8, uint64(min), uint64(max)))
+	})
 }
 
 // AsUint8DefaultBetween returns the uint8 representation of the value
@@ -66,19 +132,47 @@ func AsUint8Between(b Bucket, k string, min, max int8) uint8 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUint8DefaultBetween(b Bucket, k string, v, min, max int8) uint8 {
-	return uint8(asUintDefault(b, k, 8, uint64(v), uint64(min), uint64(max)))
+	return uint8(asUintDefault(b, k, accessor{"AsUint8DefaultBetween", true}, 8, uint64(v), uint64(min), uint64(max)))
+}
+
+// TryAsUint8DefaultBetween returns the uint8 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUint8DefaultBetween(b Bucket, k string, v, min, max int8) (uint8, error) {
+	return try(func() uint8 {
+		return uint8(asUintDefault(b, k, accessor{"TryAsUint8DefaultBetween", true}, 8, uint64(v), uint64(min), uint64(max)))
+	})
 }
 
 // AsUint16 returns the uint16 representation of the value associated with k or
 // panics if unable to do so.
 func AsUint16(b Bucket, k string) uint16 {
-	return uint16(asUint(b, k, 16, 0, math.MaxUint16))
+	return uint16(asUint(b, k, accessor{"AsUint16", false}, 16, 0, math.MaxUint16))
+}
+
+// TryAsUint16 returns the uint16 representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsUint16(b Bucket, k string) (uint16, error) {
+	return try(func() uint16 {
+		return uint16(asUint(b, k, accessor{"TryAsUint16", false}, 16, 0, math.MaxUint16))
+	})
 }
 
 // AsUint16Default returns the uint16 representation of the value associated
 // with k, or the default value v if k is undefined.
 func AsUint16Default(b Bucket, k string, v uint16) uint16 {
-	return uint16(asUintDefault(b, k, 16, uint64(v), 0, math.MaxUint16))
+	return uint16(asUintDefault(b, k, accessor{"AsUint16Default", false}, 16, uint64(v), 0, math.MaxUint16))
+}
+
+// TryAsUint16Default returns the uint16 representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsUint16Default(b Bucket, k string, v uint16) (uint16, error) {
+	return try(func() uint16 {
+		return uint16(asUintDefault(b, k, accessor{"TryAsUint16Default", false}, 16, uint64(v), 0, math.MaxUint16))
+	})
 }
 
 // AsUint16Between returns the uint16 representation of the value associated
@@ -86,7 +180,17 @@ func AsUint16Default(b Bucket, k string, v uint16) uint16 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUint16Between(b Bucket, k string, min, max int16) uint16 {
-	return uint16(asUint(b, k, 16, uint64(min), uint64(max)))
+	return uint16(asUint(b, k, accessor{"AsUint16Between", true}, 16, uint64(min), uint64(max)))
+}
+
+// TryAsUint16Between returns the uint16 representation of the value associated
+// with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUint16Between(b Bucket, k string, min, max int16) (uint16, error) {
+	return try(func() uint16 {
+		return uint16(asUint(b, k, accessor{"TryAsUint16Between", true}, 16, uint64(min), uint64(max)))
+	})
 }
 
 // AsUint16DefaultBetween returns the uint16 representation of the value
@@ -94,19 +198,47 @@ func AsUint16Between(b Bucket, k string, min, max int16) uint16 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUint16DefaultBetween(b Bucket, k string, v, min, max int16) uint16 {
-	return uint16(asUintDefault(b, k, 16, uint64(v), uint64(min), uint64(max)))
+	return uint16(asUintDefault(b, k, accessor{"AsUint16DefaultBetween", true}, 16, uint64(v), uint64(min), uint64(max)))
+}
+
+// TryAsUint16DefaultBetween returns the uint16 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUint16DefaultBetween(b Bucket, k string, v, min, max int16) (uint16, error) {
+	return try(func() uint16 {
+		return uint16(asUintDefault(b, k, accessor{"TryAsUint16DefaultBetween", true}, 16, uint64(v), uint64(min), uint64(max)))
+	})
 }
 
 // AsUint32 returns the uint32 representation of the value associated with k or
 // panics if unable to do so.
 func AsUint32(b Bucket, k string) uint32 {
-	return uint32(asUint(b, k, 32, 0, math.MaxUint32))
+	return uint32(asUint(b, k, accessor{"AsUint32", false}, 32, 0, math.MaxUint32))
+}
+
+// TryAsUint32 returns the uint32 representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsUint32(b Bucket, k string) (uint32, error) {
+	return try(func() uint32 {
+		return uint32(asUint(b, k, accessor{"TryAsUint32", false}, 32, 0, math.MaxUint32))
+	})
 }
 
 // AsUint32Default returns the uint32 representation of the value associated
 // with k, or the default value v if k is undefined.
 func AsUint32Default(b Bucket, k string, v uint32) uint32 {
-	return uint32(asUintDefault(b, k, 32, uint64(v), 0, math.MaxUint32))
+	return uint32(asUintDefault(b, k, accessor{"AsUint32Default", false}, 32, uint64(v), 0, math.MaxUint32))
+}
+
+// TryAsUint32Default returns the uint32 representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsUint32Default(b Bucket, k string, v uint32) (uint32, error) {
+	return try(func() uint32 {
+		return uint32(asUintDefault(b, k, accessor{"TryAsUint32Default", false}, 32, uint64(v), 0, math.MaxUint32))
+	})
 }
 
 // AsUint32Between returns the uint32 representation of the value associated
@@ -114,7 +246,17 @@ func AsUint32Default(b Bucket, k string, v uint32) uint32 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUint32Between(b Bucket, k string, min, max int32) uint32 {
-	return uint32(asUint(b, k, 32, uint64(min), uint64(max)))
+	return uint32(asUint(b, k, accessor{"AsUint32Between", true}, 32, uint64(min), uint64(max)))
+}
+
+// TryAsUint32Between returns the uint32 representation of the value associated
+// with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUint32Between(b Bucket, k string, min, max int32) (uint32, error) {
+	return try(func() uint32 {
+		return uint32(asUint(b, k, accessor{"TryAsUint32Between", true}, 32, uint64(min), uint64(max)))
+	})
 }
 
 // AsUint32DefaultBetween returns the uint32 representation of the value
@@ -122,19 +264,47 @@ func AsUint32Between(b Bucket, k string, min, max int32) uint32 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUint32DefaultBetween(b Bucket, k string, v, min, max int32) uint32 {
-	return uint32(asUintDefault(b, k, 32, uint64(v), uint64(min), uint64(max)))
+	return uint32(asUintDefault(b, k, accessor{"AsUint32DefaultBetween", true}, 32, uint64(v), uint64(min), uint64(max)))
+}
+
+// TryAsUint32DefaultBetween returns the uint32 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUint32DefaultBetween(b Bucket, k string, v, min, max int32) (uint32, error) {
+	return try(func() uint32 {
+		return uint32(asUintDefault(b, k, accessor{"TryAsUint32DefaultBetween", true}, 32, uint64(v), uint64(min), uint64(max)))
+	})
 }
 
 // AsUint64 returns the uint64 representation of the value associated with k or
 // panics if unable to do so.
 func AsUint64(b Bucket, k string) uint64 {
-	return asUint(b, k, 64, 0, math.MaxUint64)
+	return asUint(b, k, accessor{"AsUint64", false}, 64, 0, math.MaxUint64)
+}
+
+// TryAsUint64 returns the uint64 representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsUint64(b Bucket, k string) (uint64, error) {
+	return try(func() uint64 {
+		return asUint(b, k, accessor{"TryAsUint64", false}, 64, 0, math.MaxUint64)
+	})
 }
 
 // AsUint64Default returns the uint64 representation of the value associated
 // with k, or the default value v if k is undefined.
 func AsUint64Default(b Bucket, k string, v uint64) uint64 {
-	return asUintDefault(b, k, 64, v, 0, math.MaxUint64)
+	return asUintDefault(b, k, accessor{"AsUint64Default", false}, 64, v, 0, math.MaxUint64)
+}
+
+// TryAsUint64Default returns the uint64 representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsUint64Default(b Bucket, k string, v uint64) (uint64, error) {
+	return try(func() uint64 {
+		return asUintDefault(b, k, accessor{"TryAsUint64Default", false}, 64, v, 0, math.MaxUint64)
+	})
 }
 
 // AsUint64Between returns the uint64 representation of the value associated
@@ -142,7 +312,17 @@ func AsUint64Default(b Bucket, k string, v uint64) uint64 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUint64Between(b Bucket, k string, min, max uint64) uint64 {
-	return asUint(b, k, 64, min, max)
+	return asUint(b, k, accessor{"AsUint64Between", true}, 64, min, max)
+}
+
+// TryAsUint64Between returns the uint64 representation of the value associated
+// with k, or an error if unable to do so.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUint64Between(b Bucket, k string, min, max uint64) (uint64, error) {
+	return try(func() uint64 {
+		return asUint(b, k, accessor{"TryAsUint64Between", true}, 64, min, max)
+	})
 }
 
 // AsUint64DefaultBetween returns the uint64 representation of the value
@@ -150,22 +330,35 @@ func AsUint64Between(b Bucket, k string, min, max uint64) uint64 {
 //
 // It panics if the value is not between min and max (inclusive).
 func AsUint64DefaultBetween(b Bucket, k string, v, min, max uint64) uint64 {
-	return asUintDefault(b, k, 64, v, min, max)
+	return asUintDefault(b, k, accessor{"AsUint64DefaultBetween", true}, 64, v, min, max)
+}
+
+// TryAsUint64DefaultBetween returns the uint64 representation of the value
+// associated with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is not between min and max (inclusive).
+func TryAsUint64DefaultBetween(b Bucket, k string, v, min, max uint64) (uint64, error) {
+	return try(func() uint64 {
+		return asUintDefault(b, k, accessor{"TryAsUint64DefaultBetween", true}, 64, v, min, max)
+	})
 }
 
 func tryAsUint(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	min, max uint64,
 ) (uint64, bool) {
-	x := b.Get(k)
+	annotateRange(b, k, a, min, max)
+
+	x := access(b, k, a)
 
 	if x.IsZero() {
 		return 0, false
 	}
 
-	s := mustAsString(k, x)
+	s := mustAsString(qualify(b, k), x)
 	v, err := strconv.ParseUint(
 		s,
 		10,
@@ -176,39 +369,44 @@ func tryAsUint(
 	}
 
 	panic(InvalidValue{
-		k,
-		s,
-		fmt.Sprintf(`expected an integer between %d and %d (inclusive)`,
+		Key:   qualify(b, k),
+		Value: s,
+		Explanation: fmt.Sprintf(`expected an integer between %d and %d (inclusive)`,
 			min,
 			max,
 		),
+		Source: x.Source(),
 	})
 }
 
 func asUint(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	min, max uint64,
 ) uint64 {
-	if v, ok := tryAsUint(b, k, bitSize, min, max); ok {
+	if v, ok := tryAsUint(b, k, a, bitSize, min, max); ok {
 		return v
 	}
 
-	panic(NotDefined{k})
+	panic(notDefined(b, k))
 }
 
 func asUintDefault(
 	b Bucket,
 	k string,
+	a accessor,
 	bitSize int,
 	d, min, max uint64,
 ) uint64 {
+	annotateDefault(b, k, a, d)
+
 	if min > d || d > max {
 		panic(InvalidDefaultValue{
-			k,
-			fmt.Sprintf(`%d`, d),
-			fmt.Sprintf(
+			Key:          qualify(b, k),
+			DefaultValue: fmt.Sprintf(`%d`, d),
+			Explanation: fmt.Sprintf(
 				`expected an integer between %d and %d (inclusive)`,
 				min,
 				max,
@@ -216,7 +414,7 @@ func asUintDefault(
 		})
 	}
 
-	if v, ok := tryAsUint(b, k, bitSize, min, max); ok {
+	if v, ok := tryAsUint(b, k, a, bitSize, min, max); ok {
 		return v
 	}
 
@@ -8,57 +8,96 @@ import (
 // AsURL returns the url.URL representation of the value associated with k or
 // panics if unable to do so.
 func AsURL(b Bucket, k string) *url.URL {
-	if v, ok := tryAsURL(b, k); ok {
-		return v
-	}
+	return asURL(b, k, accessor{"AsURL", false})
+}
 
-	panic(NotDefined{k})
+// TryAsURL returns the url.URL representation of the value associated with k,
+// or an error if unable to do so.
+func TryAsURL(b Bucket, k string) (*url.URL, error) {
+	return try(func() *url.URL {
+		return asURL(b, k, accessor{"TryAsURL", false})
+	})
 }
 
 // AsURLDefault returns the url.URL representation of the value associated with
 // k, or the default value v if k is undefined.
 func AsURLDefault(b Bucket, k, v string) *url.URL {
-	if v, ok := tryAsURL(b, k); ok {
-		return v
+	return asURLDefault(b, k, accessor{"AsURLDefault", false}, v)
+}
+
+// TryAsURLDefault returns the url.URL representation of the value associated
+// with k, or the default value v if k is undefined.
+//
+// It returns an error if the value is invalid.
+func TryAsURLDefault(b Bucket, k, v string) (*url.URL, error) {
+	return try(func() *url.URL {
+		return asURLDefault(b, k, accessor{"TryAsURLDefault", false}, v)
+	})
+}
+
+func tryAsURL(
+	b Bucket,
+	k string,
+	a accessor,
+) (*url.URL, bool) {
+	x := access(b, k, a)
+
+	if x.IsZero() {
+		return nil, false
 	}
 
-	u, err := url.Parse(v)
+	s := mustAsString(qualify(b, k), x)
+	v, err := url.Parse(s)
 	if err != nil {
-		panic(InvalidDefaultValue{
-			k,
-			v,
-			fmt.Sprintf(
+		panic(InvalidValue{
+			Key:   qualify(b, k),
+			Value: s,
+			Explanation: fmt.Sprintf(
 				`expected a URL (%s)`,
 				err.(*url.Error).Unwrap(),
 			),
+			Source: x.Source(),
 		})
 	}
 
-	return u
+	return v, true
 }
 
-func tryAsURL(
+func asURL(
 	b Bucket,
 	k string,
-) (*url.URL, bool) {
-	x := b.Get(k)
+	a accessor,
+) *url.URL {
+	if v, ok := tryAsURL(b, k, a); ok {
+		return v
+	}
 
-	if x.IsZero() {
-		return nil, false
+	panic(notDefined(b, k))
+}
+
+func asURLDefault(
+	b Bucket,
+	k string,
+	a accessor,
+	d string,
+) *url.URL {
+	annotateDefault(b, k, a, d)
+
+	if v, ok := tryAsURL(b, k, a); ok {
+		return v
 	}
 
-	s := mustAsString(k, x)
-	v, err := url.Parse(s)
+	u, err := url.Parse(d)
 	if err != nil {
-		panic(InvalidValue{
-			k,
-			s,
-			fmt.Sprintf(
+		panic(InvalidDefaultValue{
+			Key:          qualify(b, k),
+			DefaultValue: d,
+			Explanation: fmt.Sprintf(
 				`expected a URL (%s)`,
 				err.(*url.Error).Unwrap(),
 			),
 		})
 	}
 
-	return v, true
+	return u
 }
@@ -74,6 +74,19 @@ func (v Value) AsBytes() ([]byte, error) {
 	return v.src.AsBytes()
 }
 
+// Source returns a human-readable description of where the value was obtained,
+// such as the name of an environment variable or the path to a file.
+//
+// It returns an empty string if v is the zero-value, or if the source is
+// unknown, such as for values created by String() or Bytes().
+func (v Value) Source() string {
+	if v.src == nil {
+		return ""
+	}
+
+	return v.src.Source()
+}
+
 // String returns the value as a string, or panics if unable to do so.
 func (v Value) String() string {
 	s, err := v.AsString()
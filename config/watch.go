@@ -0,0 +1,608 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Watchable is a Bucket with content that may change while the application is
+// running.
+type Watchable interface {
+	Bucket
+
+	// Subscribe arranges for fn to be called when the value associated with
+	// any of the given keys changes.
+	//
+	// fn is called once for each changed key, after the new values have been
+	// made visible to readers. old or new is the zero-value if the key was
+	// previously or is now undefined, respectively.
+	//
+	// It returns a function that cancels the subscription.
+	Subscribe(keys []string, fn func(old, new Value)) (cancel func())
+}
+
+// WatchOption is an option that changes the behavior of a watched bucket.
+type WatchOption func(*watchOptions)
+
+// watchOptions is the set of options applied to a watched bucket.
+type watchOptions struct {
+	interval time.Duration
+	debounce time.Duration
+	onError  func(error)
+}
+
+// WatchInterval returns an option that sets how often the underlying files are
+// checked for changes.
+//
+// The default interval is 1 second. The bucket can not be created if d is not
+// positive.
+func WatchInterval(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.interval = d
+	}
+}
+
+// WatchDebounce returns an option that sets how long a change must remain
+// stable before it is applied.
+//
+// This prevents readers from observing intermediate states while the files
+// are being rewritten. The default is to apply changes as soon as they are
+// detected.
+func WatchDebounce(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.debounce = d
+	}
+}
+
+// WatchErrorHandler returns an option that sets a function that is called
+// when the underlying files can not be reloaded.
+//
+// It is called when a reload first fails, and again only if a subsequent
+// reload fails with a different error.
+//
+// The last good configuration remains in use after a reload failure.
+func WatchErrorHandler(fn func(error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = fn
+	}
+}
+
+// WatchFile returns a bucket containing a single key, k, the value of which is
+// the content of the file at path p.
+//
+// The file is checked for changes periodically, as per the WatchInterval()
+// option. It returns an error if the file can not be read initially.
+func WatchFile(k, p string, opts ...WatchOption) (*WatchedBucket, error) {
+	return watch(
+		func() (Bucket, error) {
+			buf, err := os.ReadFile(p)
+			if err != nil {
+				return nil, err
+			}
+
+			return Map{k: described(Bytes(buf), "file "+p)}, nil
+		},
+		opts,
+	)
+}
+
+// WatchDotEnv returns a bucket that produces configuration values from the
+// "dotenv" file at path p.
+//
+// See DotEnv() for a description of the supported syntax. The file is checked
+// for changes periodically, as per the WatchInterval() option. It returns an
+// error if the file can not be read or parsed initially.
+func WatchDotEnv(p string, opts ...WatchOption) (*WatchedBucket, error) {
+	return watch(
+		func() (Bucket, error) {
+			return DotEnv(p)
+		},
+		opts,
+	)
+}
+
+// WatchDirectory returns a bucket that produces configuration values from the
+// files in the directory at path p.
+//
+// See Directory() for a description of how keys map to files. The directory is
+// checked for changes periodically, as per the WatchInterval() option. It
+// returns an error if the directory can not be read initially.
+func WatchDirectory(p string, opts ...WatchOption) (*WatchedBucket, error) {
+	return watch(
+		func() (Bucket, error) {
+			return Directory(p)
+		},
+		opts,
+	)
+}
+
+// WatchedBucket is an implementation of Watchable that reloads its content
+// when the underlying source changes.
+//
+// The content of each value is read when the bucket is loaded, such that
+// readers always observe a consistent set of values. Consequently,
+// Value.AsPath() returns the path to a temporary file.
+type WatchedBucket struct {
+	load     watchLoader
+	blocking bool
+	opts     watchOptions
+
+	current atomic.Pointer[watchSnapshot]
+
+	// loading serializes the loading and applying of snapshots, such that an
+	// older snapshot never replaces a newer one.
+	loading sync.Mutex
+
+	m         sync.Mutex
+	err       error
+	pending   watchSnapshot
+	since     time.Time
+	subs      map[*watchSubscription]struct{}
+	reloads   int                // the number of calls to Reload() that are waiting to load
+	interrupt context.CancelFunc // cancels the load in progress, if any
+
+	// notifications is the queue of subscriber calls that have not yet been
+	// made. notifying is true while a goroutine is making those calls.
+	notifications []func()
+	notifying     bool
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+// watchLoader is a function that loads the content of a WatchedBucket.
+//
+// If block is true, the loader may block until the content differs from the
+// content it returned previously, or ctx is canceled.
+type watchLoader func(ctx context.Context, block bool) (Bucket, error)
+
+// watchSnapshot is the content of a WatchedBucket at a point in time.
+type watchSnapshot map[string]watchedValue
+
+// watchedValue is a value within a watchSnapshot.
+type watchedValue struct {
+	data   []byte
+	source string
+}
+
+// value returns v as a Value.
+func (v watchedValue) value() Value {
+	return described(Bytes(v.data), v.source)
+}
+
+// watchSubscription is a subscription created by WatchedBucket.Subscribe().
+type watchSubscription struct {
+	keys map[string]struct{}
+	fn   func(old, new Value)
+}
+
+// watch returns a new WatchedBucket that uses load to obtain its content,
+// checking for changes periodically.
+func watch(load func() (Bucket, error), opts []WatchOption) (*WatchedBucket, error) {
+	return newWatchedBucket(
+		func(context.Context, bool) (Bucket, error) {
+			return load()
+		},
+		false,
+		opts,
+	)
+}
+
+// newWatchedBucket returns a new WatchedBucket that uses load to obtain its
+// content.
+//
+// If blocking is true, load is expected to block until the content changes,
+// and so it is called again as soon as it returns. Otherwise, it is called
+// periodically, as per the WatchInterval() option.
+func newWatchedBucket(
+	load watchLoader,
+	blocking bool,
+	opts []WatchOption,
+) (*WatchedBucket, error) {
+	o := watchOptions{
+		interval: 1 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.interval <= 0 {
+		return nil, fmt.Errorf("watch interval must be positive, got %s", o.interval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	b := &WatchedBucket{
+		load:     load,
+		blocking: blocking,
+		opts:     o,
+		subs:     map[*watchSubscription]struct{}{},
+		ctx:      ctx,
+		cancel:   cancel,
+		stopped:  make(chan struct{}),
+	}
+
+	snap, err := b.snapshot(ctx, false)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	b.current.Store(&snap)
+
+	go b.run()
+
+	return b, nil
+}
+
+// Get returns the value associated with the given key.
+//
+// If they key is not defined, it returns a zero-value.
+func (b *WatchedBucket) Get(k string) Value {
+	if v, ok := (*b.current.Load())[k]; ok {
+		return v.value()
+	}
+
+	return Value{}
+}
+
+// GetDefault returns the value associated with the given key.
+//
+// If the key is not defined, it returns a value with the content of v.
+func (b *WatchedBucket) GetDefault(k string, v string) Value {
+	if x, ok := (*b.current.Load())[k]; ok {
+		return x.value()
+	}
+
+	return String(v)
+}
+
+// Each calls fn for each key/value pair in the bucket.
+//
+// If fn returns false, iteration is stopped.
+//
+// Each returns true if iteration completes fully, or false if fn()
+// returns false.
+func (b *WatchedBucket) Each(fn EachFunc) bool {
+	for k, v := range *b.current.Load() {
+		if !fn(k, v.value()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Subscribe arranges for fn to be called when the value associated with any
+// of the given keys changes.
+//
+// fn is called once for each changed key, after the new values have been made
+// visible to readers. old or new is the zero-value if the key was previously
+// or is now undefined, respectively.
+//
+// Calls to fn are made one at a time, in the order the changes were applied.
+// fn may call Reload() or Close().
+//
+// It returns a function that cancels the subscription.
+func (b *WatchedBucket) Subscribe(keys []string, fn func(old, new Value)) (cancel func()) {
+	s := &watchSubscription{
+		keys: map[string]struct{}{},
+		fn:   fn,
+	}
+
+	for _, k := range keys {
+		s.keys[k] = struct{}{}
+	}
+
+	b.m.Lock()
+	b.subs[s] = struct{}{}
+	b.m.Unlock()
+
+	return func() {
+		b.m.Lock()
+		delete(b.subs, s)
+		b.m.Unlock()
+	}
+}
+
+// Err returns the error that occurred during the most recent reload, if any.
+//
+// It returns nil once a subsequent reload succeeds.
+func (b *WatchedBucket) Err() error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.err
+}
+
+// Reload reloads the content of the bucket immediately, without regard to the
+// WatchDebounce() option.
+//
+// If the content can not be loaded, the last good configuration remains in
+// use and the error is returned.
+//
+// Subscribers are notified of any changes before Reload() returns, unless
+// notifications are already being delivered, such as when Reload() is called
+// by a subscriber. In that case the changes are delivered after those that
+// are already queued.
+func (b *WatchedBucket) Reload() error {
+	// Interrupt any load that is in progress, which may be blocked waiting for
+	// a change, and prevent subsequent loads from blocking until this reload
+	// is complete.
+	b.m.Lock()
+	b.reloads++
+	if b.interrupt != nil {
+		b.interrupt()
+	}
+	b.m.Unlock()
+
+	b.loading.Lock()
+
+	snap, err := b.snapshot(context.Background(), false)
+
+	b.m.Lock()
+	b.reloads--
+	b.err = err
+	if err != nil {
+		b.m.Unlock()
+		b.loading.Unlock()
+		return err
+	}
+
+	b.pending = nil
+	deliver := b.apply(snap)
+	b.m.Unlock()
+	b.loading.Unlock()
+
+	if deliver {
+		b.notify()
+	}
+
+	return nil
+}
+
+// Close stops watching for changes.
+//
+// The bucket continues to produce the last loaded configuration.
+func (b *WatchedBucket) Close() error {
+	b.cancel()
+	<-b.stopped
+
+	return nil
+}
+
+// run checks for changes until the bucket is closed.
+func (b *WatchedBucket) run() {
+	defer close(b.stopped)
+
+	t := time.NewTicker(b.opts.interval)
+	defer t.Stop()
+
+	for {
+		block := b.blocking && b.canBlock()
+
+		if block {
+			if b.ctx.Err() != nil {
+				return
+			}
+		} else {
+			select {
+			case <-b.ctx.Done():
+				return
+			case <-t.C:
+			}
+		}
+
+		b.poll(block)
+	}
+}
+
+// canBlock returns true if the next load may block until the content changes.
+//
+// It returns false if the previous load failed, such that it is retried after
+// the watch interval, or if a change is pending, such that it is applied once
+// the debounce period has elapsed.
+func (b *WatchedBucket) canBlock() bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.err == nil && b.pending == nil
+}
+
+// poll checks for changes, applying them once they have been stable for the
+// debounce period.
+func (b *WatchedBucket) poll(block bool) {
+	b.loading.Lock()
+	defer b.loading.Unlock()
+
+	ctx, cancel := context.WithCancel(b.ctx)
+	defer cancel()
+
+	b.m.Lock()
+	b.interrupt = cancel
+	if b.reloads != 0 {
+		block = false
+	}
+	b.m.Unlock()
+
+	snap, err := b.snapshot(ctx, block)
+
+	b.m.Lock()
+	b.interrupt = nil
+
+	if ctx.Err() != nil {
+		// The bucket was closed, or Reload() was called, while loading.
+		b.m.Unlock()
+		return
+	}
+
+	prev := b.err
+	b.err = err
+	if err != nil {
+		b.m.Unlock()
+
+		if b.opts.onError != nil && !sameError(prev, err) {
+			b.opts.onError(err)
+		}
+
+		return
+	}
+
+	now := time.Now()
+	deliver := false
+
+	if equalSnapshots(snap, *b.current.Load()) {
+		b.pending = nil
+	} else {
+		if b.pending == nil || !equalSnapshots(snap, b.pending) {
+			b.pending = snap
+			b.since = now
+		}
+
+		if now.Sub(b.since) >= b.opts.debounce {
+			b.pending = nil
+			deliver = b.apply(snap)
+		}
+	}
+	b.m.Unlock()
+
+	// Subscribers are notified on a separate goroutine, such that they may
+	// call Reload() or Close() without waiting for this goroutine.
+	if deliver {
+		go b.notify()
+	}
+}
+
+// apply makes snap the current content of the bucket, and queues notifications
+// for the subscribers of any changed keys.
+//
+// It returns true if the caller must deliver the notifications by calling
+// notify(). b.m must be locked when apply() is called, but not when notify()
+// is called.
+func (b *WatchedBucket) apply(snap watchSnapshot) bool {
+	prev := *b.current.Load()
+	b.current.Store(&snap)
+
+	for s := range b.subs {
+		for k := range s.keys {
+			o, hadOld := prev[k]
+			n, hasNew := snap[k]
+
+			if hadOld == hasNew && bytes.Equal(o.data, n.data) {
+				continue
+			}
+
+			var before, after Value
+			if hadOld {
+				before = o.value()
+			}
+			if hasNew {
+				after = n.value()
+			}
+
+			fn := s.fn
+			b.notifications = append(b.notifications, func() {
+				fn(before, after)
+			})
+		}
+	}
+
+	if b.notifying || len(b.notifications) == 0 {
+		return false
+	}
+
+	b.notifying = true
+	return true
+}
+
+// notify calls subscribers until there are no queued notifications.
+//
+// Only one goroutine delivers notifications at a time, such that subscribers
+// observe changes in the order they were applied. It must only be called
+// after apply() returns true.
+func (b *WatchedBucket) notify() {
+	for {
+		b.m.Lock()
+		calls := b.notifications
+		b.notifications = nil
+		if len(calls) == 0 {
+			b.notifying = false
+		}
+		b.m.Unlock()
+
+		if len(calls) == 0 {
+			return
+		}
+
+		for _, c := range calls {
+			c()
+		}
+	}
+}
+
+// snapshot loads the content of the bucket and reads all of its values.
+func (b *WatchedBucket) snapshot(ctx context.Context, block bool) (watchSnapshot, error) {
+	src, err := b.load(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := watchSnapshot{}
+
+	visit := func(k string, v Value) bool {
+		if v.IsZero() {
+			return true
+		}
+
+		var buf []byte
+		buf, err = v.AsBytes()
+		if err != nil {
+			err = unreadable(k, err)
+			return false
+		}
+
+		snap[k] = watchedValue{buf, v.Source()}
+		return true
+	}
+
+	// A bucket that can not enumerate its keys appears empty, which must not
+	// be mistaken for the removal of every key.
+	if f, ok := src.(fallibleBucket); ok {
+		if _, e := f.tryEach(visit); e != nil {
+			return nil, e
+		}
+	} else {
+		src.Each(visit)
+	}
+
+	return snap, err
+}
+
+// sameError returns true if a and b are both non-nil and describe the same
+// failure.
+func sameError(a, b error) bool {
+	return a != nil && b != nil && a.Error() == b.Error()
+}
+
+// equalSnapshots returns true if a and b have the same content.
+func equalSnapshots(a, b watchSnapshot) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k, x := range a {
+		y, ok := b[k]
+		if !ok || !bytes.Equal(x.data, y.data) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,415 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ Watchable = (*WatchedBucket)(nil)
+
+var _ = Describe("type WatchedBucket", func() {
+	var dir string
+
+	// write atomically replaces the content of the file with the given name.
+	write := func(name, content string) {
+		tmp := filepath.Join(dir, "."+name+".tmp")
+		err := os.WriteFile(tmp, []byte(content), 0600)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		err = os.Rename(tmp, filepath.Join(dir, name))
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("func WatchFile()", func() {
+		It("returns a bucket containing the content of the file", func() {
+			write("toggle", "<value>")
+
+			bucket, err := WatchFile("TOGGLE", filepath.Join(dir, "toggle"), WatchInterval(5*time.Millisecond))
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			Expect(bucket.Get("TOGGLE").String()).To(Equal("<value>"))
+
+			write("toggle", "<updated>")
+
+			Eventually(func() string {
+				return bucket.Get("TOGGLE").String()
+			}).Should(Equal("<updated>"))
+		})
+
+		It("returns an error if the file can not be read", func() {
+			_, err := WatchFile("TOGGLE", filepath.Join(dir, "does-not-exist"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Describe("func WatchDotEnv()", func() {
+		var bucket *WatchedBucket
+
+		BeforeEach(func() {
+			write("app.env", "RATE_LIMIT=10\nDEBUG=false\n")
+
+			var err error
+			bucket, err = WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(5*time.Millisecond))
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			bucket.Close()
+		})
+
+		It("returns an error if the file is not well-formed", func() {
+			write("invalid.env", "=")
+
+			_, err := WatchDotEnv(filepath.Join(dir, "invalid.env"))
+			Expect(err).To(BeAssignableToTypeOf(SyntaxError{}))
+		})
+
+		It("swaps all values at once", func() {
+			write("app.env", "RATE_LIMIT=20\nDEBUG=true\n")
+
+			Eventually(func() string {
+				return bucket.Get("RATE_LIMIT").String()
+			}).Should(Equal("20"))
+
+			Expect(bucket.Get("DEBUG").String()).To(Equal("true"))
+		})
+
+		It("notifies subscribers of changes to the keys they are interested in", func() {
+			var (
+				m       sync.Mutex
+				changes [][2]string
+			)
+
+			bucket.Subscribe(
+				[]string{"RATE_LIMIT", "NEW"},
+				func(old, new Value) {
+					m.Lock()
+					defer m.Unlock()
+
+					var o, n string
+					if !old.IsZero() {
+						o = old.String()
+					}
+					if !new.IsZero() {
+						n = new.String()
+					}
+
+					changes = append(changes, [2]string{o, n})
+				},
+			)
+
+			write("app.env", "RATE_LIMIT=20\nDEBUG=true\nNEW=<new>\n")
+
+			Eventually(func() [][2]string {
+				m.Lock()
+				defer m.Unlock()
+				return append([][2]string(nil), changes...)
+			}).Should(ConsistOf(
+				[2]string{"10", "20"},
+				[2]string{"", "<new>"},
+			))
+		})
+
+		It("does not notify cancelled subscriptions", func() {
+			cancel := bucket.Subscribe(
+				[]string{"RATE_LIMIT"},
+				func(old, new Value) {
+					defer GinkgoRecover()
+					Fail("unexpected call")
+				},
+			)
+			cancel()
+
+			write("app.env", "RATE_LIMIT=20\n")
+
+			Eventually(func() string {
+				return bucket.Get("RATE_LIMIT").String()
+			}).Should(Equal("20"))
+		})
+
+		It("keeps the last good configuration if the file can not be reloaded", func() {
+			write("app.env", "RATE_LIMIT=20\n")
+
+			Eventually(func() string {
+				return bucket.Get("RATE_LIMIT").String()
+			}).Should(Equal("20"))
+
+			write("app.env", "RATE_LIMIT='30\n")
+
+			Eventually(bucket.Err).Should(Equal(SyntaxError{
+				Path:        filepath.Join(dir, "app.env"),
+				Line:        1,
+				Explanation: "unterminated single-quoted value",
+			}))
+
+			Expect(bucket.Get("RATE_LIMIT").String()).To(Equal("20"))
+
+			write("app.env", "RATE_LIMIT=30\n")
+
+			Eventually(func() string {
+				return bucket.Get("RATE_LIMIT").String()
+			}).Should(Equal("30"))
+
+			Expect(bucket.Err()).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Describe("func WatchDirectory()", func() {
+		It("returns a bucket containing the files in the directory", func() {
+			write("KEY", "<value>")
+
+			bucket, err := WatchDirectory(dir, WatchInterval(5*time.Millisecond))
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			Expect(bucket.Get("KEY").String()).To(Equal("<value>"))
+
+			write("OTHER", "<other>")
+
+			Eventually(func() bool {
+				v := bucket.GetDefault("OTHER", "<default>")
+				return v.String() == "<other>"
+			}).Should(BeTrue())
+
+			var keys []string
+			bucket.Each(func(k string, v Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			Expect(keys).To(ConsistOf("KEY", "OTHER"))
+		})
+
+		It("keeps the last good configuration if the directory can not be read", func() {
+			write("KEY", "<value>")
+
+			bucket, err := WatchDirectory(dir, WatchInterval(time.Hour))
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			err = os.RemoveAll(dir)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			err = bucket.Reload()
+			Expect(err).Should(HaveOccurred())
+			Expect(bucket.Err()).To(Equal(err))
+			Expect(bucket.Get("KEY").String()).To(Equal("<value>"))
+		})
+	})
+
+	Describe("func WatchErrorHandler()", func() {
+		It("reports reload failures to the handler", func() {
+			write("app.env", "KEY=<value>\n")
+
+			errs := make(chan error, 100)
+
+			bucket, err := WatchDotEnv(
+				filepath.Join(dir, "app.env"),
+				WatchInterval(5*time.Millisecond),
+				WatchErrorHandler(func(err error) {
+					errs <- err
+				}),
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			os.Remove(filepath.Join(dir, "app.env"))
+
+			var reported error
+			Eventually(errs).Should(Receive(&reported))
+			Expect(os.IsNotExist(reported)).To(BeTrue())
+			Expect(bucket.Get("KEY").String()).To(Equal("<value>"))
+		})
+
+		It("does not report the same failure repeatedly", func() {
+			write("app.env", "KEY=<value>\n")
+
+			errs := make(chan error, 100)
+
+			bucket, err := WatchDotEnv(
+				filepath.Join(dir, "app.env"),
+				WatchInterval(5*time.Millisecond),
+				WatchErrorHandler(func(err error) {
+					errs <- err
+				}),
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			write("app.env", "<invalid>\n")
+
+			Eventually(errs).Should(Receive())
+			Consistently(errs, 100*time.Millisecond).ShouldNot(Receive())
+
+			write("app.env", "KEY=<value>\n")
+
+			Eventually(bucket.Err).Should(BeNil())
+
+			write("app.env", "<invalid>\n")
+
+			Eventually(errs).Should(Receive())
+		})
+	})
+
+	Describe("func WatchInterval()", func() {
+		It("returns an error if the interval is not positive", func() {
+			write("app.env", "KEY=<value>\n")
+
+			_, err := WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(0))
+			Expect(err).To(MatchError("watch interval must be positive, got 0s"))
+
+			_, err = WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(-time.Second))
+			Expect(err).To(MatchError("watch interval must be positive, got -1s"))
+		})
+	})
+
+	Describe("func WatchDebounce()", func() {
+		It("delays changes until they have been stable for the debounce period", func() {
+			write("app.env", "KEY=<value>\n")
+
+			bucket, err := WatchDotEnv(
+				filepath.Join(dir, "app.env"),
+				WatchInterval(5*time.Millisecond),
+				WatchDebounce(250*time.Millisecond),
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			write("app.env", "KEY=<updated>\n")
+
+			Consistently(func() string {
+				return bucket.Get("KEY").String()
+			}, 100*time.Millisecond).Should(Equal("<value>"))
+
+			Eventually(func() string {
+				return bucket.Get("KEY").String()
+			}).Should(Equal("<updated>"))
+		})
+	})
+
+	Describe("func Reload()", func() {
+		It("applies changes immediately", func() {
+			write("app.env", "KEY=<value>\n")
+
+			bucket, err := WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(time.Hour))
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			write("app.env", "KEY=<updated>\n")
+
+			err = bucket.Reload()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(bucket.Get("KEY").String()).To(Equal("<updated>"))
+		})
+
+		It("notifies subscribers before returning", func() {
+			write("app.env", "KEY=<value>\n")
+
+			bucket, err := WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(time.Hour))
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			var changes []string
+			bucket.Subscribe(
+				[]string{"KEY"},
+				func(old, new Value) {
+					changes = append(changes, new.String())
+				},
+			)
+
+			write("app.env", "KEY=<updated>\n")
+
+			err = bucket.Reload()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(changes).To(Equal([]string{"<updated>"}))
+		})
+
+		It("may be called by a subscriber when a change is detected", func() {
+			write("app.env", "KEY=<value>\n")
+
+			bucket, err := WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(10*time.Millisecond))
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			result := make(chan error, 1)
+			bucket.Subscribe(
+				[]string{"KEY"},
+				func(old, new Value) {
+					select {
+					case result <- bucket.Reload():
+					default:
+					}
+				},
+			)
+
+			write("app.env", "KEY=<updated>\n")
+
+			Eventually(result).Should(Receive(BeNil()))
+		})
+
+		It("may be called by a subscriber during a reload", func() {
+			write("app.env", "KEY=<value>\n")
+
+			bucket, err := WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(time.Hour))
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			var changes []string
+			bucket.Subscribe(
+				[]string{"KEY"},
+				func(old, new Value) {
+					changes = append(changes, new.String())
+
+					if new.String() == "<updated>" {
+						write("app.env", "KEY=<updated again>\n")
+						Expect(bucket.Reload()).To(Succeed())
+					}
+				},
+			)
+
+			write("app.env", "KEY=<updated>\n")
+
+			err = bucket.Reload()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(changes).To(Equal([]string{"<updated>", "<updated again>"}))
+		})
+	})
+
+	Describe("func Close()", func() {
+		It("may be called by a subscriber", func() {
+			write("app.env", "KEY=<value>\n")
+
+			bucket, err := WatchDotEnv(filepath.Join(dir, "app.env"), WatchInterval(10*time.Millisecond))
+			Expect(err).ShouldNot(HaveOccurred())
+			defer bucket.Close()
+
+			closed := make(chan error, 1)
+			bucket.Subscribe(
+				[]string{"KEY"},
+				func(old, new Value) {
+					closed <- bucket.Close()
+				},
+			)
+
+			write("app.env", "KEY=<updated>\n")
+
+			Eventually(closed).Should(Receive(BeNil()))
+		})
+	})
+})
@@ -0,0 +1,225 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFile returns a Bucket that produces configuration values from the YAML
+// document at path p.
+//
+// The document must contain a single mapping. See JSONFile() for a description
+// of how the document is mapped to keys. Anchors, aliases and merge keys are
+// supported.
+//
+// It returns a SyntaxError if the document is not well-formed, or if two keys
+// in the document map to the same bucket key.
+func YAMLFile(p string, opts ...DocumentOption) (Bucket, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseYAML(p, f, opts)
+}
+
+// YAMLReader returns a Bucket that produces configuration values from the YAML
+// document in r.
+//
+// See JSONFile() for a description of how the document is mapped to keys.
+func YAMLReader(r io.Reader, opts ...DocumentOption) (Bucket, error) {
+	return parseYAML("", r, opts)
+}
+
+// parseYAML parses the YAML document in r and returns a bucket containing its
+// values.
+//
+// p is the path to the file, used only for error reporting.
+func parseYAML(p string, r io.Reader, opts []DocumentOption) (Bucket, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(buf)).Decode(&doc); err == io.EOF {
+		return Map{}, nil
+	} else if err != nil {
+		return nil, yamlSyntaxError(p, err)
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		if root.Tag == "!!null" {
+			return Map{}, nil
+		}
+
+		return nil, SyntaxError{
+			Path:        p,
+			Line:        root.Line,
+			Explanation: "expected the document to be a YAML mapping",
+		}
+	}
+
+	n, err := (&yamlConverter{p}).convert(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return flattenDocument(p, n, opts)
+}
+
+// yamlErrorPattern matches the line number in the errors produced by the YAML
+// decoder.
+var yamlErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.+)$`)
+
+// yamlSyntaxError returns a SyntaxError that describes an error produced by
+// the YAML decoder.
+func yamlSyntaxError(p string, err error) SyntaxError {
+	e := SyntaxError{
+		Path:        p,
+		Line:        1,
+		Explanation: strings.TrimPrefix(err.Error(), "yaml: "),
+	}
+
+	if m := yamlErrorPattern.FindStringSubmatch(err.Error()); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		e.Explanation = m[2]
+	}
+
+	return e
+}
+
+// yamlConverter converts YAML nodes to docNode values.
+type yamlConverter struct {
+	path string
+}
+
+// convert returns the docNode equivalent of n.
+func (c *yamlConverter) convert(n *yaml.Node) (docNode, error) {
+	switch n.Kind {
+	case yaml.AliasNode:
+		x, err := c.convert(n.Alias)
+		x.Line = n.Line
+		return x, err
+
+	case yaml.MappingNode:
+		x := docNode{
+			Line: n.Line,
+			Kind: docObject,
+		}
+
+		if err := c.addMembers(&x, n); err != nil {
+			return docNode{}, err
+		}
+
+		return x, nil
+
+	case yaml.SequenceNode:
+		x := docNode{
+			Line: n.Line,
+			Kind: docArray,
+		}
+
+		for _, e := range n.Content {
+			v, err := c.convert(e)
+			if err != nil {
+				return docNode{}, err
+			}
+
+			x.Elements = append(x.Elements, v)
+		}
+
+		return x, nil
+
+	default:
+		if n.Tag == "!!null" {
+			return docNode{Line: n.Line, Kind: docNull}, nil
+		}
+
+		return docNode{Line: n.Line, Kind: docScalar, Scalar: n.Value}, nil
+	}
+}
+
+// addMembers adds the key/value pairs in the mapping node n to x.
+//
+// Keys that are defined explicitly take precedence over those obtained via
+// merge keys.
+func (c *yamlConverter) addMembers(x *docNode, n *yaml.Node) error {
+	var merged docNode
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+
+		if k.Kind != yaml.ScalarNode {
+			return SyntaxError{
+				Path:        c.path,
+				Line:        k.Line,
+				Explanation: "mapping keys must be scalars",
+			}
+		}
+
+		if k.Tag == "!!merge" {
+			if err := c.merge(&merged, v); err != nil {
+				return err
+			}
+			continue
+		}
+
+		m, err := c.convert(v)
+		if err != nil {
+			return err
+		}
+
+		x.Members = append(x.Members, docMember{k.Value, m})
+	}
+
+	defined := map[string]struct{}{}
+	for _, m := range x.Members {
+		defined[m.Name] = struct{}{}
+	}
+
+	for _, m := range merged.Members {
+		if _, ok := defined[m.Name]; !ok {
+			defined[m.Name] = struct{}{}
+			x.Members = append(x.Members, m)
+		}
+	}
+
+	return nil
+}
+
+// merge adds the key/value pairs in the mapping (or sequence of mappings)
+// referenced by a merge key to x.
+func (c *yamlConverter) merge(x *docNode, n *yaml.Node) error {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		return c.addMembers(x, n)
+
+	case yaml.SequenceNode:
+		for _, e := range n.Content {
+			if err := c.merge(x, e); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return SyntaxError{
+			Path:        c.path,
+			Line:        n.Line,
+			Explanation: "merge keys must refer to a mapping",
+		}
+	}
+}
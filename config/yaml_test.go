@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"strings"
+
+	. "github.com/dogmatiq/dodeca/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("func YAMLFile()", func() {
+	It("returns a bucket containing the values in the file", func() {
+		b, err := YAMLFile("./testdata/example.yaml")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(b.Get("HTTP_TIMEOUT").String()).To(Equal("5s"))
+		Expect(b.Get("HTTP_READ_TIMEOUT").String()).To(Equal("10s"))
+		Expect(b.Get("SERVERS_0_HOST").String()).To(Equal("a.example.org"))
+		Expect(b.Get("SERVERS_1_PORT").String()).To(Equal("8081"))
+		Expect(b.Get("SERVERS_1_PORT").Source()).To(Equal("line 9 of ./testdata/example.yaml"))
+	})
+
+	It("returns an error if the file does not exist", func() {
+		_, err := YAMLFile("./testdata/does-not-exist.yaml")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("includes the path in syntax errors", func() {
+		_, err := YAMLFile("./testdata/example.env")
+		Expect(err).To(MatchError(
+			`syntax error in ./testdata/example.env on line 2: expected the document to be a YAML mapping`,
+		))
+	})
+})
+
+var _ = Describe("func YAMLReader()", func() {
+	parse := func(s string, opts ...DocumentOption) Bucket {
+		b, err := YAMLReader(strings.NewReader(s), opts...)
+		Expect(err).ShouldNot(HaveOccurred())
+		return b
+	}
+
+	DescribeTable(
+		"it maps values to keys",
+		func(content, key, expect string) {
+			b := parse(content)
+			Expect(b.Get(key).String()).To(Equal(expect))
+		},
+		Entry("string", "key: value", "KEY", "value"),
+		Entry("quoted string", `key: "  value  "`, "KEY", "  value  "),
+		Entry("integer", "key: 123", "KEY", "123"),
+		Entry("boolean", "key: false", "KEY", "false"),
+		Entry("multi-line string", "key: |\n  a\n  b\n", "KEY", "a\nb\n"),
+		Entry("nested mapping", "http:\n  timeout: 5s", "HTTP_TIMEOUT", "5s"),
+		Entry("sequence of scalars", "key: [a, 1, true]", "KEY", "a,1,true"),
+		Entry("sequence of scalars that require quoting", "key:\n  - a,b\n  - ' c '", "KEY", `"a,b"," c "`),
+		Entry("sequence of mappings", "servers:\n  - host: a\n  - host: b", "SERVERS_1_HOST", "b"),
+		Entry("alias", "a: &x 5s\nb: *x", "B", "5s"),
+		Entry("merge key", "base: &base\n  a: 1\n  b: 2\nderived:\n  <<: *base\n  b: 3", "DERIVED_A", "1"),
+		Entry("merge key overridden", "base: &base\n  a: 1\n  b: 2\nderived:\n  <<: *base\n  b: 3", "DERIVED_B", "3"),
+	)
+
+	It("exposes sequences of scalars to the list functions", func() {
+		b := parse("key:\n  - 1\n  - 2\n  - 3\n")
+		Expect(AsIntSlice(b, "KEY")).To(Equal([]int{1, 2, 3}))
+	})
+
+	It("treats null values and empty strings as undefined", func() {
+		b := parse("a: null\nb: ''\nc: ~\nd:")
+
+		var v Value
+		v = b.Get("A")
+		Expect(v.IsZero()).To(BeTrue())
+		v = b.Get("B")
+		Expect(v.IsZero()).To(BeTrue())
+		v = b.Get("C")
+		Expect(v.IsZero()).To(BeTrue())
+		v = b.Get("D")
+		Expect(v.IsZero()).To(BeTrue())
+	})
+
+	It("returns an empty bucket if the document is empty", func() {
+		b := parse("# comment\n")
+		Expect(b.Each(func(string, Value) bool {
+			return false
+		})).To(BeTrue())
+	})
+
+	It("supports a custom key separator and case", func() {
+		b := parse(
+			"http:\n  timeout: 5s",
+			DocumentKeySeparator("."),
+			DocumentKeyCase(strings.ToLower),
+		)
+		Expect(b.Get("http.timeout").String()).To(Equal("5s"))
+	})
+
+	DescribeTable(
+		"it returns a syntax error",
+		func(content, expect string) {
+			_, err := YAMLReader(strings.NewReader(content))
+			Expect(err).To(MatchError(expect))
+		},
+		Entry("invalid indentation", "a: 1\n b: 2\nc: 3", `syntax error on line 2: mapping values are not allowed in this context`),
+		Entry("top-level sequence", "- 1\n- 2", `syntax error on line 1: expected the document to be a YAML mapping`),
+		Entry("non-scalar key", "? [a, b]\n: 1", `syntax error on line 1: mapping keys must be scalars`),
+		Entry("duplicate key", "http_timeout: 1\nhttp:\n  timeout: 2", `syntax error on line 3: HTTP_TIMEOUT is already defined on line 1`),
+	)
+})